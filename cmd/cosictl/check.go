@@ -0,0 +1,96 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/driver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+// runCheck tests connectivity to the object storage platform of every connection, or of the selected one.
+func runCheck(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+
+	flags := newFlagSet("check", stderr, &common)
+	id := flags.String("id", "", "ID of the checked connection; all connections are checked, if empty")
+	timeout := flags.Duration("timeout", defaultTimeout, "timeout of the check of a single connection")
+
+	if err := parseFlags(flags, args, &common); err != nil {
+		return err
+	}
+
+	cfg, err := config.New(common.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to create configuration: %w", err)
+	}
+
+	checked, failed := 0, 0
+
+	for _, connection := range cfg.Connections {
+		connectionID := connectionID(connection)
+		if *id != "" && connectionID != *id {
+			continue
+		}
+
+		checked++
+
+		if err := checkConnection(ctx, connection, *timeout); err != nil {
+			failed++
+
+			fmt.Fprintf(stdout, "FAIL\t%s\t%v\n", connectionID, err)
+
+			continue
+		}
+
+		fmt.Fprintf(stdout, "OK\t%s\n", connectionID)
+	}
+
+	switch {
+	case *id != "" && checked == 0:
+		return fmt.Errorf("connection %s not found", *id)
+	case failed > 0:
+		return fmt.Errorf("%d of %d connection(s) failed", failed, checked)
+	}
+
+	return nil
+}
+
+// checkConnection creates the driver for the connection, and probes its object storage platform.
+func checkConnection(ctx context.Context, connection config.Configuration, timeout time.Duration) error {
+	d, err := driver.ProvisionerNewVirtualDriverFunc(connection)
+	if err != nil {
+		return err
+	}
+
+	prober, ok := d.(virtualdriver.Prober)
+	if !ok {
+		return errors.New("connectivity check is not supported by the platform")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return prober.Probe(ctx)
+}
+
+// connectionID returns the ID of the connection, as used in the parameters of the BucketClasses.
+func connectionID(connection config.Configuration) string {
+	if connection.Objectscale != nil {
+		return connection.Objectscale.Id
+	}
+
+	return ""
+}
@@ -0,0 +1,172 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+// Package main implements cosictl, the command line tool for troubleshooting the driver configuration
+// and the object storage platforms directly, without Kubernetes.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/driver"
+	"github.com/dell/cosi/pkg/provisioner"
+	"github.com/dell/csmlog"
+)
+
+const (
+	defaultConfigFile = "/cosi/config.yaml"
+	defaultTimeout    = 30 * time.Second
+)
+
+// command is a single cosictl subcommand.
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+}
+
+var commands = []command{
+	{name: "validate", description: "validate the config file against the config schema", run: runValidate},
+	{name: "check", description: "test connectivity to the object storage platforms", run: runCheck},
+	{name: "reconcile", description: "report orphans left by failed operations, and remove them on request", run: runReconcile},
+	{name: "create-bucket", description: "create a bucket", run: runCreateBucket},
+	{name: "delete-bucket", description: "delete a bucket", run: runDeleteBucket},
+	{name: "grant-access", description: "grant access to a bucket", run: runGrantAccess},
+	{name: "revoke-access", description: "revoke access to a bucket", run: runRevokeAccess},
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command from the arguments, and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	for _, c := range commands {
+		if c.name != args[0] {
+			continue
+		}
+
+		err := c.run(ctx, args[1:], stdout, stderr)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		default:
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "Unknown command %q\n\n", args[0])
+	usage(stderr)
+
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: cosictl <command> [flags]\n\nCommands:\n")
+
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", c.name, c.description)
+	}
+
+	fmt.Fprintf(w, "\nRun 'cosictl <command> -h' for the flags of the command.\n")
+}
+
+// errUsage is returned, when the flags of the command are invalid. The problem is already reported with the usage.
+var errUsage = errors.New("invalid usage")
+
+// commonFlags are the flags shared by all commands.
+type commonFlags struct {
+	configFile string
+	verbose    bool
+}
+
+// newFlagSet creates the flag set of the command, with the common flags.
+func newFlagSet(name string, stderr io.Writer, common *commonFlags) *flag.FlagSet {
+	flags := flag.NewFlagSet("cosictl "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&common.configFile, "config", defaultConfigFile, "path to config file")
+	flags.BoolVar(&common.verbose, "verbose", false, "log the driver operations")
+
+	return flags
+}
+
+// parseFlags parses the arguments of the command, and sets the log level.
+func parseFlags(flags *flag.FlagSet, args []string, common *commonFlags) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "unexpected arguments: %v\n", flags.Args())
+		flags.Usage()
+
+		return errUsage
+	}
+
+	// the driver logs are noise for the tool, unless troubleshooting the driver itself
+	if common.verbose {
+		csmlog.SetLevel(csmlog.DebugLevel)
+	} else {
+		csmlog.SetLevel(csmlog.WarnLevel)
+	}
+
+	return nil
+}
+
+// requireFlags reports the missing required flags with the usage.
+func requireFlags(flags *flag.FlagSet, names ...string) error {
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	for _, name := range names {
+		if !set[name] {
+			fmt.Fprintf(flags.Output(), "flag -%s is required\n", name)
+			flags.Usage()
+
+			return errUsage
+		}
+	}
+
+	return nil
+}
+
+// loadDriverset loads the config file, and creates the drivers the same way the driver does.
+func loadDriverset(configFile string) (*provisioner.Driverset, error) {
+	cfg, err := config.New(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create configuration: %w", err)
+	}
+
+	return driver.NewDriverset(cfg)
+}
@@ -0,0 +1,363 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/driver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+)
+
+const testConfig = `connections:
+- objectscale:
+    id: fake
+    credentials:
+      username: testuser
+      password: testpassword
+    mgmt-endpoint: https://example.com
+    protocols:
+      s3:
+        endpoint: https://s3.example.com
+    tls:
+      insecure: true
+`
+
+// probedDriver is a fake driver, which checks connectivity to its platform.
+type probedDriver struct {
+	fake.Driver
+	err error
+}
+
+func (d *probedDriver) Probe(context.Context) error {
+	return d.err
+}
+
+// reconciledDriver is a fake driver, which reports a single orphan.
+type reconciledDriver struct {
+	fake.Driver
+	err error
+}
+
+func (d *reconciledDriver) Reconcile(_ context.Context, remove bool) (*virtualdriver.OrphanReport, error) {
+	return &virtualdriver.OrphanReport{
+		Users:   []virtualdriver.OrphanUser{{Namespace: "namespace", Name: "orphan"}},
+		Removed: remove && d.err == nil,
+	}, d.err
+}
+
+// dryRunDriver is a fake driver, which synthesizes the created bucket in dry run.
+type dryRunDriver struct {
+	fake.Driver
+	dryRun bool
+}
+
+func (d *dryRunDriver) EnableDryRun() {
+	d.dryRun = true
+}
+
+func (d *dryRunDriver) DriverCreateBucket(ctx context.Context, req *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	if d.dryRun {
+		return &cosi.DriverCreateBucketResponse{BucketId: d.ID() + "-dry-run"}, nil
+	}
+
+	return d.Driver.DriverCreateBucket(ctx, req)
+}
+
+// withDrivers replaces the drivers created from the config for the duration of the test.
+func withDrivers(t *testing.T, newDriver func(config.Configuration) (virtualdriver.Driver, error)) {
+	previous := driver.ProvisionerNewVirtualDriverFunc
+	t.Cleanup(func() { driver.ProvisionerNewVirtualDriverFunc = previous })
+	driver.ProvisionerNewVirtualDriverFunc = newDriver
+}
+
+// writeConfig writes the config file, and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	file := path.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	return file
+}
+
+// runCommand runs cosictl with the arguments, and returns the exit code with the outputs.
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	code, _, stderr := runCommand()
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: cosictl <command>")
+
+	code, _, stderr = runCommand("unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `Unknown command "unknown"`)
+
+	code, _, stderr = runCommand("validate", "-h")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr, "-config")
+
+	code, _, _ = runCommand("validate", "--unknown-flag")
+	assert.Equal(t, 2, code)
+
+	code, _, stderr = runCommand("validate", "extra")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "unexpected arguments")
+}
+
+func TestValidate(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Valid": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &fake.Driver{FakeID: cfg.Objectscale.Id}, nil
+			})
+
+			code, stdout, _ := runCommand("validate", "--config", writeConfig(t, testConfig))
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, "is valid, 1 connection(s) configured")
+		},
+		"SchemaViolations": func(t *testing.T) {
+			code, _, stderr := runCommand("validate", "--config", writeConfig(t, "connections:\n- objectscale:\n    id: 42\n"))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "line 3, column 5: $.connections[0].objectscale: missing required property credentials")
+			assert.Contains(t, stderr, "line 3, column 9: $.connections[0].objectscale.id: expected string, got integer")
+		},
+		"UnknownProperty": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &fake.Driver{FakeID: cfg.Objectscale.Id}, nil
+			})
+
+			file := writeConfig(t, testConfig+"    emptyBuckets: true\n")
+
+			code, stdout, _ := runCommand("validate", "--config", file)
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, "is valid, 1 connection(s) configured")
+
+			code, _, stderr := runCommand("validate", "--strict", "--config", file)
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "line 13, column 5: $.connections[0].objectscale.emptyBuckets: unknown property emptyBuckets")
+		},
+		"InvalidDriverConfig": func(t *testing.T) {
+			withDrivers(t, func(config.Configuration) (virtualdriver.Driver, error) {
+				return nil, errors.New("invalid policy template")
+			})
+
+			code, _, stderr := runCommand("validate", "--config", writeConfig(t, testConfig))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "invalid policy template")
+		},
+		"MissingFile": func(t *testing.T) {
+			code, _, stderr := runCommand("validate", "--config", path.Join(t.TempDir(), "missing.yaml"))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "unable to read config file")
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	twoConnections := testConfig + `- objectscale:
+    id: other
+    credentials:
+      username: testuser
+      password: testpassword
+    mgmt-endpoint: https://other.example.com
+    protocols:
+      s3:
+        endpoint: https://s3.other.example.com
+    tls:
+      insecure: true
+`
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Available": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &probedDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}}, nil
+			})
+
+			code, stdout, _ := runCommand("check", "--config", writeConfig(t, twoConnections))
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "OK\tfake\nOK\tother\n", stdout)
+		},
+		"Unavailable": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				if cfg.Objectscale.Id == "other" {
+					return &probedDriver{Driver: fake.Driver{FakeID: "other"}, err: errors.New("connection refused")}, nil
+				}
+
+				return &probedDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}}, nil
+			})
+
+			code, stdout, stderr := runCommand("check", "--config", writeConfig(t, twoConnections))
+			assert.Equal(t, 1, code)
+			assert.Equal(t, "OK\tfake\nFAIL\tother\tconnection refused\n", stdout)
+			assert.Contains(t, stderr, "1 of 2 connection(s) failed")
+		},
+		"SelectedConnection": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &probedDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}}, nil
+			})
+
+			code, stdout, _ := runCommand("check", "--config", writeConfig(t, twoConnections), "--id", "other")
+			assert.Equal(t, 0, code)
+			assert.Equal(t, "OK\tother\n", stdout)
+
+			code, _, stderr := runCommand("check", "--config", writeConfig(t, twoConnections), "--id", "missing")
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "connection missing not found")
+		},
+		"NotSupported": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &fake.Driver{FakeID: cfg.Objectscale.Id}, nil
+			})
+
+			code, stdout, _ := runCommand("check", "--config", writeConfig(t, testConfig))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stdout, "connectivity check is not supported")
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"ReportOnly": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &reconciledDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}}, nil
+			})
+
+			code, stdout, _ := runCommand("reconcile", "--config", writeConfig(t, testConfig))
+			assert.Equal(t, 0, code)
+			assert.Equal(t, `{"id":"fake","report":{"users":[{"namespace":"namespace","name":"orphan"}],"statements":null,"removed":false}}`+"\n", stdout)
+		},
+		"Remove": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &reconciledDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}}, nil
+			})
+
+			code, stdout, _ := runCommand("reconcile", "--config", writeConfig(t, testConfig), "--id", "fake", "--remove")
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, `"removed":true`)
+		},
+		"Incomplete": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &reconciledDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}, err: errors.New("failed getting policy")}, nil
+			})
+
+			code, stdout, stderr := runCommand("reconcile", "--config", writeConfig(t, testConfig), "--remove")
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stdout, `"removed":false},"error":"failed getting policy"`)
+			assert.Contains(t, stderr, "1 of 1 connection(s) failed")
+		},
+		"NotSupported": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &fake.Driver{FakeID: cfg.Objectscale.Id}, nil
+			})
+
+			code, stdout, _ := runCommand("reconcile", "--config", writeConfig(t, testConfig))
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stdout, "reconciliation is not supported")
+
+			code, _, stderr := runCommand("reconcile", "--config", writeConfig(t, testConfig), "--id", "missing")
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "connection missing not found")
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+		return &dryRunDriver{Driver: fake.Driver{FakeID: cfg.Objectscale.Id}}, nil
+	})
+
+	configFile := writeConfig(t, testConfig)
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"CreateBucket": func(t *testing.T) {
+			code, stdout, _ := runCommand("create-bucket", "--config", configFile, "--id", "fake", "--name", "bucket")
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, `"bucketId": "fake-bucket"`)
+		},
+		"CreateBucketDryRun": func(t *testing.T) {
+			// the fake driver fails every request with the parameter, unless the dry run is enabled
+			code, stdout, _ := runCommand("create-bucket", "--config", configFile, "--id", "fake", "--name", "bucket",
+				"--param", fake.ForceFail+"=true", "--dry-run")
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, `"bucketId": "fake-dry-run"`)
+		},
+		"DryRunNotSupported": func(t *testing.T) {
+			withDrivers(t, func(cfg config.Configuration) (virtualdriver.Driver, error) {
+				return &fake.Driver{FakeID: cfg.Objectscale.Id}, nil
+			})
+
+			code, _, stderr := runCommand("create-bucket", "--config", configFile, "--id", "fake", "--name", "bucket", "--dry-run")
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "dry run is not supported by connection fake")
+		},
+		"CreateBucketFailure": func(t *testing.T) {
+			code, _, stderr := runCommand("create-bucket", "--config", configFile, "--id", "fake", "--name", "bucket",
+				"--param", fake.ForceFail+"=true")
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, "DriverCreateBucket failed")
+		},
+		"DeleteBucket": func(t *testing.T) {
+			code, _, _ := runCommand("delete-bucket", "--config", configFile, "--bucket-id", "fake-bucket")
+			assert.Equal(t, 0, code)
+		},
+		"GrantAccess": func(t *testing.T) {
+			code, stdout, _ := runCommand("grant-access", "--config", configFile, "--id", "fake", "--bucket-id", "fake-bucket",
+				"--name", "access", "--auth-type", "iam")
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, `"accountId": "fake-account"`)
+
+			code, _, stderr := runCommand("grant-access", "--config", configFile, "--id", "fake", "--bucket-id", "fake-bucket",
+				"--name", "access", "--auth-type", "token")
+			assert.Equal(t, 2, code)
+			assert.Contains(t, stderr, `invalid auth-type "token"`)
+		},
+		"RevokeAccess": func(t *testing.T) {
+			code, _, _ := runCommand("revoke-access", "--config", configFile, "--bucket-id", "fake-bucket", "--account-id", "access")
+			assert.Equal(t, 0, code)
+		},
+		"MissingFlag": func(t *testing.T) {
+			code, _, stderr := runCommand("revoke-access", "--config", configFile, "--bucket-id", "fake-bucket")
+			assert.Equal(t, 2, code)
+			assert.Contains(t, stderr, "flag -account-id is required")
+		},
+		"UnknownConnection": func(t *testing.T) {
+			code, _, stderr := runCommand("delete-bucket", "--config", configFile, "--bucket-id", "other-bucket", "--dry-run")
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, `connection "other" not found`)
+		},
+		"InvalidParameter": func(t *testing.T) {
+			code, _, _ := runCommand("create-bucket", "--config", configFile, "--id", "fake", "--name", "bucket", "--param", "value")
+			assert.Equal(t, 2, code)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/provisioner"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+// operationFlags are the flags shared by the commands sending a single request to the backend.
+type operationFlags struct {
+	commonFlags
+	timeout time.Duration
+	dryRun  bool
+}
+
+// newOperationFlagSet creates the flag set of the operation command, with the common flags.
+func newOperationFlagSet(name string, stderr io.Writer, operation *operationFlags) *flag.FlagSet {
+	flags := newFlagSet(name, stderr, &operation.commonFlags)
+	flags.DurationVar(&operation.timeout, "timeout", defaultTimeout, "timeout of the operation")
+	flags.BoolVar(&operation.dryRun, "dry-run", false, "print the mutating calls to the object storage platform with their payload, instead of sending them")
+
+	return flags
+}
+
+// runCreateBucket creates the bucket, the way the driver does for the BucketClaim.
+func runCreateBucket(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		operation operationFlags
+		params    parameters
+	)
+
+	flags := newOperationFlagSet("create-bucket", stderr, &operation)
+	id := flags.String("id", "", "ID of the connection, as the 'id' parameter of the BucketClass (required)")
+	name := flags.String("name", "", "name of the bucket (required)")
+	flags.Var(&params, "param", "additional parameter of the BucketClass, in form of key=value; can be repeated")
+
+	if err := parseFlags(flags, args, &operation.commonFlags); err != nil {
+		return err
+	}
+
+	if err := requireFlags(flags, "id", "name"); err != nil {
+		return err
+	}
+
+	if params == nil {
+		params = parameters{}
+	}
+
+	params["id"] = *id
+
+	req := &cosi.DriverCreateBucketRequest{Name: *name, Parameters: params}
+
+	return send(ctx, &operation, *id, "DriverCreateBucket", req, stdout, (*provisioner.Server).DriverCreateBucket)
+}
+
+// runDeleteBucket deletes the bucket, the way the driver does for the deleted BucketClaim.
+func runDeleteBucket(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var operation operationFlags
+
+	flags := newOperationFlagSet("delete-bucket", stderr, &operation)
+	bucketID := flags.String("bucket-id", "", "ID of the bucket, as returned by create-bucket (required)")
+
+	if err := parseFlags(flags, args, &operation.commonFlags); err != nil {
+		return err
+	}
+
+	if err := requireFlags(flags, "bucket-id"); err != nil {
+		return err
+	}
+
+	req := &cosi.DriverDeleteBucketRequest{BucketId: *bucketID}
+
+	return send(ctx, &operation, bucketConnectionID(*bucketID), "DriverDeleteBucket", req, stdout, (*provisioner.Server).DriverDeleteBucket)
+}
+
+// runGrantAccess grants access to the bucket, the way the driver does for the BucketAccess.
+func runGrantAccess(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		operation operationFlags
+		params    parameters
+	)
+
+	flags := newOperationFlagSet("grant-access", stderr, &operation)
+	id := flags.String("id", "", "ID of the connection, as the 'id' parameter of the BucketAccessClass (required)")
+	bucketID := flags.String("bucket-id", "", "ID of the bucket, as returned by create-bucket (required)")
+	name := flags.String("name", "", "name of the bucket access (required)")
+	authType := flags.String("auth-type", "key", "authentication type of the bucket access: key or iam")
+	flags.Var(&params, "param", "additional parameter of the BucketAccessClass, in form of key=value; can be repeated")
+
+	if err := parseFlags(flags, args, &operation.commonFlags); err != nil {
+		return err
+	}
+
+	if err := requireFlags(flags, "id", "bucket-id", "name"); err != nil {
+		return err
+	}
+
+	authenticationType := cosi.AuthenticationType_Key
+	switch *authType {
+	case "key":
+	case "iam":
+		authenticationType = cosi.AuthenticationType_IAM
+	default:
+		fmt.Fprintf(stderr, "invalid auth-type %q, expected key or iam\n", *authType)
+		flags.Usage()
+
+		return errUsage
+	}
+
+	if params == nil {
+		params = parameters{}
+	}
+
+	params["id"] = *id
+
+	req := &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           *bucketID,
+		Name:               *name,
+		AuthenticationType: authenticationType,
+		Parameters:         params,
+	}
+
+	return send(ctx, &operation, *id, "DriverGrantBucketAccess", req, stdout, (*provisioner.Server).DriverGrantBucketAccess)
+}
+
+// runRevokeAccess revokes access to the bucket, the way the driver does for the deleted BucketAccess.
+func runRevokeAccess(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var operation operationFlags
+
+	flags := newOperationFlagSet("revoke-access", stderr, &operation)
+	bucketID := flags.String("bucket-id", "", "ID of the bucket, as returned by create-bucket (required)")
+	accountID := flags.String("account-id", "", "ID of the account, as returned by grant-access (required)")
+
+	if err := parseFlags(flags, args, &operation.commonFlags); err != nil {
+		return err
+	}
+
+	if err := requireFlags(flags, "bucket-id", "account-id"); err != nil {
+		return err
+	}
+
+	req := &cosi.DriverRevokeBucketAccessRequest{BucketId: *bucketID, AccountId: *accountID}
+
+	return send(ctx, &operation, bucketConnectionID(*bucketID), "DriverRevokeBucketAccess", req, stdout, (*provisioner.Server).DriverRevokeBucketAccess)
+}
+
+// send sends the request through the provisioner server, the same way as the driver, and prints the response.
+// In dry run, the driver logs the mutating calls to the object storage platform instead of sending them,
+// and the printed response is synthesized.
+func send[Req, Resp proto.Message](
+	ctx context.Context,
+	operation *operationFlags,
+	id, method string,
+	req Req,
+	stdout io.Writer,
+	call func(*provisioner.Server, context.Context, Req) (Resp, error),
+) error {
+	driverset, err := loadDriverset(operation.configFile)
+	if err != nil {
+		return err
+	}
+
+	d, err := driverset.Get(id)
+	if err != nil {
+		return fmt.Errorf("connection %q not found: %w", id, err)
+	}
+
+	if operation.dryRun {
+		if err := enableDryRun(d, operation.verbose); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, operation.timeout)
+	defer cancel()
+
+	resp, err := call(provisioner.New(driverset), ctx, req)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+
+	fmt.Fprintln(stdout, marshal(resp))
+
+	return nil
+}
+
+// enableDryRun makes the driver log the mutating calls instead of sending them. The intercepted calls are logged
+// at info level, so it is enabled, unless the log is already verbose.
+func enableDryRun(d virtualdriver.Driver, verbose bool) error {
+	dryRunner, ok := d.(virtualdriver.DryRunner)
+	if !ok {
+		return fmt.Errorf("dry run is not supported by connection %s", d.ID())
+	}
+
+	dryRunner.EnableDryRun()
+
+	if !verbose {
+		csmlog.SetLevel(csmlog.InfoLevel)
+	}
+
+	return nil
+}
+
+// marshal formats the message as indented JSON.
+func marshal(m proto.Message) string {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("%v", m)
+	}
+
+	// protojson output is deliberately unstable, so it is reformatted
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, b, "", "  "); err != nil {
+		return string(b)
+	}
+
+	return indented.String()
+}
+
+// bucketConnectionID returns the ID of the connection, which is the prefix of the bucket ID created by the driver.
+func bucketConnectionID(bucketID string) string {
+	id, _, _ := strings.Cut(bucketID, "-")
+	return id
+}
+
+var _ flag.Value = (*parameters)(nil)
+
+// parameters are the key=value flags, set multiple times.
+type parameters map[string]string
+
+// String implements flag.Value interface.
+func (p *parameters) String() string {
+	return fmt.Sprint(map[string]string(*p))
+}
+
+// Set implements flag.Value interface.
+func (p *parameters) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+
+	if *p == nil {
+		*p = parameters{}
+	}
+
+	(*p)[key] = val
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/driver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+// reconciliation is the result of the reconciliation of a single connection, printed as a JSON line.
+type reconciliation struct {
+	ID     string                      `json:"id"`
+	Report *virtualdriver.OrphanReport `json:"report,omitempty"`
+	Error  string                      `json:"error,omitempty"`
+}
+
+// runReconcile detects the orphans of every connection, or of the selected one, and removes them on request.
+// By default, the orphans are only reported, so they can be reviewed before they are removed with --remove.
+func runReconcile(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+
+	flags := newFlagSet("reconcile", stderr, &common)
+	id := flags.String("id", "", "ID of the reconciled connection; all connections are reconciled, if empty")
+	remove := flags.Bool("remove", false, "remove the detected orphans; by default, they are only reported")
+	timeout := flags.Duration("timeout", defaultTimeout, "timeout of the reconciliation of a single connection")
+
+	if err := parseFlags(flags, args, &common); err != nil {
+		return err
+	}
+
+	cfg, err := config.New(common.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to create configuration: %w", err)
+	}
+
+	reconciled, failed := 0, 0
+	encoder := json.NewEncoder(stdout)
+
+	for _, connection := range cfg.Connections {
+		connectionID := connectionID(connection)
+		if *id != "" && connectionID != *id {
+			continue
+		}
+
+		reconciled++
+
+		result := reconciliation{ID: connectionID}
+
+		result.Report, err = reconcileConnection(ctx, connection, *remove, *timeout)
+		if err != nil {
+			failed++
+
+			result.Error = err.Error()
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case *id != "" && reconciled == 0:
+		return fmt.Errorf("connection %s not found", *id)
+	case failed > 0:
+		return fmt.Errorf("%d of %d connection(s) failed", failed, reconciled)
+	}
+
+	return nil
+}
+
+// reconcileConnection creates the driver for the connection, and reconciles its object storage platform.
+func reconcileConnection(ctx context.Context, connection config.Configuration, remove bool, timeout time.Duration) (*virtualdriver.OrphanReport, error) {
+	d, err := driver.ProvisionerNewVirtualDriverFunc(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciler, ok := d.(virtualdriver.Reconciler)
+	if !ok {
+		return nil, errors.New("reconciliation is not supported by the platform")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return reconciler.Reconcile(ctx, remove)
+}
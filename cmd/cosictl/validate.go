@@ -0,0 +1,59 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+// runValidate validates the config file against the config schema, and creates the drivers from it,
+// without connecting to the object storage platforms.
+func runValidate(_ context.Context, args []string, stdout, stderr io.Writer) error {
+	var common commonFlags
+
+	flags := newFlagSet("validate", stderr, &common)
+	strict := flags.Bool("strict", false, "reject the properties not defined by the config schema, instead of logging warnings")
+
+	if err := parseFlags(flags, args, &common); err != nil {
+		return err
+	}
+
+	// #nosec G304 -- the file is provided by the user running the tool
+	b, err := os.ReadFile(common.configFile)
+	if err != nil {
+		return fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	// the warnings are logged, when the config is loaded below
+	if _, err := config.Validate(b, config.Strict(*strict)); err != nil {
+		return fmt.Errorf("%s does not match the config schema:\n%w", common.configFile, err)
+	}
+
+	// the drivers validate the values, which cannot be expressed by the schema, e.g. templates and CIDRs
+	driverset, err := loadDriverset(common.configFile)
+	if err != nil {
+		return err
+	}
+
+	connections := 0
+	driverset.Range(func(_ virtualdriver.Driver) bool {
+		connections++
+		return true
+	})
+
+	fmt.Fprintf(stdout, "%s is valid, %d connection(s) configured\n", common.configFile, connections)
+
+	return nil
+}
@@ -10,8 +10,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"strings"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/dell/cosi/pkg/config"
 	"github.com/dell/cosi/pkg/driver"
+	"github.com/dell/cosi/pkg/identity"
 	"github.com/dell/csmlog"
 )
 
@@ -42,10 +45,23 @@ var (
 	otelEndpoint           = flag.String("otel-endpoint", "", "OTEL collector endpoint for collecting observability data")
 	configFile             = flag.String("config", "/cosi/config.yaml", "path to config file")
 	driverConfigParamsFile = flag.String("driver-config-params", "", "path to driver config params file")
+	address                = flag.String("address", driver.COSISocket, "address to listen on: UNIX socket path, unix://<path> or tcp://<host>:<port>")
+	tlsCertFile            = flag.String("tls-cert", "", "path to TLS certificate of the gRPC server")
+	tlsKeyFile             = flag.String("tls-key", "", "path to TLS key of the gRPC server")
+	tlsClientCAFile        = flag.String("tls-client-ca", "", "path to CA verifying client certificates of the gRPC server (mTLS)")
+	shutdownTimeout        = flag.Duration("shutdown-timeout", driver.DefaultShutdownTimeout, "time given to in-flight requests to complete on shutdown")
+	driverName             = flag.String("driver-name", "", "name of the driver, overriding the driverName from the config file (default \""+defaultDriverName+"\")")
+	infoAddress            = flag.String("info-address", "", "address serving the driver info over HTTP: UNIX socket path, unix://<path> or tcp://<host>:<port>; disabled if empty")
+	adminAddress           = flag.String("admin-address", "", "address serving the read-only admin API over HTTP without TLS: UNIX socket path, unix://<path> or tcp://<loopback host>:<port>; disabled if empty")
+	adminTokenFile         = flag.String("admin-token-file", "", "path to file with the bearer token required by the admin API")
+	strictConfig           = flag.Bool("strict-config", false, "reject the properties of the config file not defined by the config schema, instead of logging warnings")
+	dryRun                 = flag.Bool("dry-run", false, "log the mutating calls to the object storage platforms with their payload, instead of sending them")
 )
 
 const (
 	tracedServiceName = "cosi.dellemc.com"
+	// defaultDriverName is used, if the driver name is neither set by the flag nor in the config file.
+	defaultDriverName = "cosi.dellemc.com"
 	// DefaultLogLevel for logs
 	DefaultLogLevel = csmlog.InfoLevel
 	// ParamLogLevel driver log level
@@ -85,13 +101,18 @@ func runMain() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cfg, err := config.New(*configFile)
+	cfg, err := config.New(*configFile, config.Strict(*strictConfig))
 	if err != nil {
 		return fmt.Errorf("failed to create configuration: %w", err)
 	}
 
 	log.Infof("Config successfully loaded from %s", *configFile)
 
+	name, err := resolveDriverName(*driverName, cfg)
+	if err != nil {
+		return err
+	}
+
 	v := viper.New()
 	v.AutomaticEnv()
 	v.SetConfigFile(*driverConfigParamsFile)
@@ -132,22 +153,43 @@ func runMain() error {
 	sigs := make(chan os.Signal, 1)
 	// Listen for the SIGINT and SIGTERM signals.
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
 
 	// Create a goroutine to listen for signals.
 	go func() {
+		select {
 		// Wait for a signal.
-		sig := <-sigs
-		// Log that a signal was received.
-		log.Infof("Signal received: %v", sig)
-		// Cancel the context.
-		cancel()
-		// Exit the program with an error.
-		os.Exit(1)
+		case sig := <-sigs:
+			// Log that a signal was received.
+			log.Infof("Signal received: %v, shutting down", sig)
+			// Cancel the context, so the driver stops gracefully.
+			cancel()
+
+		case <-ctx.Done():
+		}
 	}()
 
 	log.Info("COSI driver is starting")
 	// Run the driver.
-	return runBlocking(ctx, cfg, tracedServiceName)
+	err = runBlocking(ctx, cfg, name)
+
+	// Flush spans of the requests completed during the shutdown.
+	if tp != nil {
+		shutdownTracerProvider(tp)
+	}
+
+	return err
+}
+
+// shutdownTracerProvider exports the buffered spans and stops the TracerProvider.
+func shutdownTracerProvider(tp *sdktrace.TracerProvider) {
+	// The main context is already canceled, so the shutdown uses its own deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+
+	if err := tp.Shutdown(ctx); err != nil {
+		log.Errorf("failed to shut down tracer provider: %v", err)
+	}
 }
 
 func updateDriverConfigParams(ctx context.Context, v *viper.Viper) error {
@@ -185,8 +227,146 @@ func updateDriverConfigParams(ctx context.Context, v *viper.Viper) error {
 	return nil
 }
 
-var runBlocking = func(ctx context.Context, cfg *config.ConfigSchemaJson, tracedServiceName string) error {
-	return driver.RunBlocking(ctx, cfg, driver.COSISocket, tracedServiceName)
+// resolveDriverName returns the driver name from the flag, the config file or the default, in this order of precedence.
+func resolveDriverName(flagValue string, cfg *config.ConfigSchemaJson) (string, error) {
+	name := defaultDriverName
+
+	switch {
+	case flagValue != "":
+		name = flagValue
+	case cfg.DriverName != nil && *cfg.DriverName != "":
+		name = *cfg.DriverName
+	}
+
+	if err := identity.ValidateName(name); err != nil {
+		return "", err
+	}
+
+	log.Infof("Driver name set to %s", name)
+
+	return name, nil
+}
+
+var runBlocking = func(ctx context.Context, cfg *config.ConfigSchemaJson, name string) error {
+	options, err := serverOptions(*tlsCertFile, *tlsKeyFile, *tlsClientCAFile)
+	if err != nil {
+		return err
+	}
+
+	// The HTTP listeners are created first, so the driver does not start if the addresses are not available.
+	var listeners []net.Listener
+	closeListeners := func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}
+
+	var infoListener net.Listener
+	if *infoAddress != "" {
+		infoListener, err = driver.Listen(*infoAddress)
+		if err != nil {
+			return fmt.Errorf("failed to serve driver info: %w", err)
+		}
+
+		listeners = append(listeners, infoListener)
+	}
+
+	var (
+		adminListener net.Listener
+		adminToken    string
+	)
+
+	if *adminAddress != "" {
+		adminToken, err = readAdminToken(*adminTokenFile)
+		if err != nil {
+			closeListeners()
+			return err
+		}
+
+		adminListener, err = driver.Listen(*adminAddress)
+		if err != nil {
+			closeListeners()
+			return fmt.Errorf("failed to serve admin API: %w", err)
+		}
+
+		listeners = append(listeners, adminListener)
+	}
+
+	d, err := driver.New(cfg, *address, name, options...)
+	if err != nil {
+		closeListeners()
+		return err
+	}
+
+	// The driver is not served on the failures below, so its listener and socket are released as well.
+	closeAll := func() {
+		closeListeners()
+
+		if err := d.Close(); err != nil {
+			log.Errorf("failed to close driver listener: %v", err)
+		}
+	}
+
+	if *dryRun {
+		if err := d.EnableDryRun(); err != nil {
+			closeAll()
+			return err
+		}
+	}
+
+	if infoListener != nil {
+		d.ServeInfo(ctx, infoListener)
+	}
+
+	if adminListener != nil {
+		if err := d.ServeAdmin(ctx, adminListener, adminToken); err != nil {
+			closeAll()
+			return err
+		}
+	}
+
+	return d.Serve(ctx, *shutdownTimeout)
+}
+
+// readAdminToken reads the bearer token of the admin API from the file.
+func readAdminToken(file string) (string, error) {
+	if file == "" {
+		return "", errors.New("admin-address requires admin-token-file")
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read admin token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("admin token file %s is empty", file)
+	}
+
+	return token, nil
+}
+
+// serverOptions returns gRPC server options enabling TLS, if the certificate and key are provided.
+func serverOptions(certFile, keyFile, clientCAFile string) ([]grpc.ServerOption, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("tls-client-ca requires tls-cert and tls-key")
+		}
+
+		return nil, nil
+	}
+
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both tls-cert and tls-key must be provided")
+	}
+
+	option, err := driver.ServerTLSOption(certFile, keyFile, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.ServerOption{option}, nil
 }
 
 var newResource = func(ctx context.Context) (*resource.Resource, error) {
@@ -10,10 +10,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/driver"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -217,3 +224,222 @@ func TestUpdateDriverConfigParams(t *testing.T) {
 		})
 	}
 }
+
+func TestServerOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		certFile     string
+		keyFile      string
+		clientCAFile string
+		expectedLen  int
+		expectErr    bool
+	}{
+		{
+			name:        "TLS disabled",
+			expectedLen: 0,
+			expectErr:   false,
+		},
+		{
+			name:      "Missing key",
+			certFile:  "tls.crt",
+			expectErr: true,
+		},
+		{
+			name:         "Client CA without certificate",
+			clientCAFile: "ca.crt",
+			expectErr:    true,
+		},
+		{
+			name:      "Non-existing certificate",
+			certFile:  "missing.crt",
+			keyFile:   "missing.key",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := serverOptions(tt.certFile, tt.keyFile, tt.clientCAFile)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("got error %v, expected error %v", err, tt.expectErr)
+			}
+
+			if len(options) != tt.expectedLen {
+				t.Errorf("got %d options, expected %d", len(options), tt.expectedLen)
+			}
+		})
+	}
+}
+
+func TestResolveDriverName(t *testing.T) {
+	configured := "cosi-b.dellemc.com"
+	invalid := "cosi_b"
+
+	tests := []struct {
+		name      string
+		flagValue string
+		cfg       *config.ConfigSchemaJson
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Default",
+			cfg:      &config.ConfigSchemaJson{},
+			expected: defaultDriverName,
+		},
+		{
+			name:     "From config file",
+			cfg:      &config.ConfigSchemaJson{DriverName: &configured},
+			expected: configured,
+		},
+		{
+			name:      "Flag overrides config file",
+			flagValue: "cosi-c.dellemc.com",
+			cfg:       &config.ConfigSchemaJson{DriverName: &configured},
+			expected:  "cosi-c.dellemc.com",
+		},
+		{
+			name:      "Invalid name",
+			cfg:       &config.ConfigSchemaJson{DriverName: &invalid},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := resolveDriverName(tt.flagValue, tt.cfg)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("got error %v, expected error %v", err, tt.expectErr)
+			}
+
+			if name != tt.expected {
+				t.Errorf("got name %q, expected %q", name, tt.expected)
+			}
+		})
+	}
+}
+
+// defaultRunBlocking is the runBlocking function, which is replaced in TestRunMain.
+var defaultRunBlocking = runBlocking
+
+func TestRunBlockingServesInfo(t *testing.T) {
+	dir := t.TempDir()
+	socket := path.Join(dir, "cosi.sock")
+	infoSocket := path.Join(dir, "info.sock")
+
+	defer func(a, i string) { *address, *infoAddress = a, i }(*address, *infoAddress)
+	*address, *infoAddress = socket, infoSocket
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- defaultRunBlocking(ctx, &config.ConfigSchemaJson{}, "cosi-b.dellemc.com")
+	}()
+
+	// wait for the info socket, requests are served once the driver starts
+	for _, err := os.Stat(infoSocket); err != nil; _, err = os.Stat(infoSocket) {
+		select {
+		case err := <-runErr:
+			t.Fatalf("driver stopped: %v", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", infoSocket)
+		},
+	}}
+
+	resp, err := client.Get("http://driver" + driver.InfoPath)
+	if err != nil {
+		t.Fatalf("failed to get driver info: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var info driver.Info
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		t.Fatalf("failed to decode driver info: %v", err)
+	}
+
+	if info.Name != "cosi-b.dellemc.com" {
+		t.Errorf("got name %q, expected %q", info.Name, "cosi-b.dellemc.com")
+	}
+
+	cancel()
+
+	if err := <-runErr; err != nil {
+		t.Errorf("got unexpected error %v", err)
+	}
+}
+
+func TestRunBlockingInvalidInfoAddress(t *testing.T) {
+	defer func(a, i string) { *address, *infoAddress = a, i }(*address, *infoAddress)
+	*address, *infoAddress = path.Join(t.TempDir(), "cosi.sock"), "http://localhost:8080"
+
+	err := defaultRunBlocking(context.Background(), &config.ConfigSchemaJson{}, defaultDriverName)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestReadAdminToken(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := path.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("secret-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := path.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte(" \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		file      string
+		expected  string
+		expectErr bool
+	}{
+		{name: "Token", file: tokenFile, expected: "secret-token"},
+		{name: "No file", expectErr: true},
+		{name: "Missing file", file: path.Join(dir, "missing"), expectErr: true},
+		{name: "Empty file", file: emptyFile, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := readAdminToken(tt.file)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("got error %v, expected error %v", err, tt.expectErr)
+			}
+
+			if token != tt.expected {
+				t.Errorf("got token %q, expected %q", token, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunBlockingAdminRequiresToken(t *testing.T) {
+	dir := t.TempDir()
+	infoSocket := path.Join(dir, "info.sock")
+
+	defer func(a, i, ad, tf string) {
+		*address, *infoAddress, *adminAddress, *adminTokenFile = a, i, ad, tf
+	}(*address, *infoAddress, *adminAddress, *adminTokenFile)
+	*address, *infoAddress, *adminAddress, *adminTokenFile = path.Join(dir, "cosi.sock"), infoSocket, path.Join(dir, "admin.sock"), ""
+
+	err := defaultRunBlocking(context.Background(), &config.ConfigSchemaJson{}, defaultDriverName)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	// the info listener is closed, when the driver does not start
+	if _, err := os.Stat(infoSocket); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("info socket was not removed: %v", err)
+	}
+}
@@ -5,12 +5,19 @@ package config
 import "encoding/json"
 import "fmt"
 import yaml "gopkg.in/yaml.v3"
+import "reflect"
 
 // this file contains JSON schema for Dell COSI Driver Configuration file
 type ConfigSchemaJson struct {
 	// List of connections to object storage platforms that can be used for object
 	// storage provisioning.
 	Connections []Configuration `json:"connections,omitempty" yaml:"connections,omitempty" mapstructure:"connections,omitempty"`
+
+	// Name of the driver, referenced by the driverName of BucketClasses and
+	// BucketAccessClasses. Must be unique, when several instances of the driver run
+	// in one cluster. Overridden by the --driver-name flag. Defaults to
+	// 'cosi.dellemc.com'
+	DriverName *string `json:"driverName,omitempty" yaml:"driverName,omitempty" mapstructure:"driverName,omitempty"`
 }
 
 // Configuration for single connection to object storage platform that is used for
@@ -29,11 +36,85 @@ type Credentials struct {
 	Username string `json:"username" yaml:"username" mapstructure:"username"`
 }
 
+// Limits of the load put on the ObjectScale by the driver operations. Operations
+// over the limits are queued until the deadline of the request, and then
+// rejected. If not set, operations are not limited
+type Limits struct {
+	// Maximum number of driver operations started at once, over the
+	// requestsPerSecond rate. Defaults to requestsPerSecond rounded up
+	Burst *int `json:"burst,omitempty" yaml:"burst,omitempty" mapstructure:"burst,omitempty"`
+
+	// Maximum number of driver operations in progress at the same time
+	MaxInFlight *int `json:"maxInFlight,omitempty" yaml:"maxInFlight,omitempty" mapstructure:"maxInFlight,omitempty"`
+
+	// Maximum average number of driver operations started per second
+	RequestsPerSecond *float64 `json:"requestsPerSecond,omitempty" yaml:"requestsPerSecond,omitempty" mapstructure:"requestsPerSecond,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Limits) UnmarshalJSON(b []byte) error {
+	type Plain Limits
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if plain.Burst != nil && 1 > *plain.Burst {
+		return fmt.Errorf("field %s: must be >= %v", "burst", 1)
+	}
+	if plain.MaxInFlight != nil && 1 > *plain.MaxInFlight {
+		return fmt.Errorf("field %s: must be >= %v", "maxInFlight", 1)
+	}
+	if plain.RequestsPerSecond != nil && 0 >= *plain.RequestsPerSecond {
+		return fmt.Errorf("field %s: must be > %v", "requestsPerSecond", 0)
+	}
+	*j = Limits(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Limits) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Limits
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Burst != nil && 1 > *plain.Burst {
+		return fmt.Errorf("field %s: must be >= %v", "burst", 1)
+	}
+	if plain.MaxInFlight != nil && 1 > *plain.MaxInFlight {
+		return fmt.Errorf("field %s: must be >= %v", "maxInFlight", 1)
+	}
+	if plain.RequestsPerSecond != nil && 0 >= *plain.RequestsPerSecond {
+		return fmt.Errorf("field %s: must be > %v", "requestsPerSecond", 0)
+	}
+	*j = Limits(plain)
+	return nil
+}
+
 // Configuration specific to the ObjectScale platform
 type Objectscale struct {
+	// Method of granting bucket access. 'bucketPolicy' adds statement for every user
+	// to the bucket policy, 'group' adds users to the IAM group with managed policy
+	// created for the bucket and access mode
+	AccessManagement ObjectscaleAccessManagement `json:"accessManagement,omitempty" yaml:"accessManagement,omitempty" mapstructure:"accessManagement,omitempty"`
+
+	// Default list of source CIDRs, from which the credentials minted for bucket
+	// access can be used. Can be narrowed by the 'allowedSourceCidrs' parameter of
+	// the BucketAccessClass
+	AllowedSourceCidrs []string `json:"allowedSourceCidrs,omitempty" yaml:"allowedSourceCidrs,omitempty" mapstructure:"allowedSourceCidrs,omitempty"`
+
+	// Identifier of the cluster, recorded in the IAM path and tags of users and roles
+	// created for bucket access. Users created by other clusters are never deleted.
+	// Defaults to the connection id
+	ClusterId *string `json:"clusterId,omitempty" yaml:"clusterId,omitempty" mapstructure:"clusterId,omitempty"`
+
 	// Credentials corresponds to the JSON schema field "credentials".
 	Credentials Credentials `json:"credentials" yaml:"credentials" mapstructure:"credentials"`
 
+	// List of additional namespaces, in which users can be created for bucket
+	// access. Selected using the 'userNamespace' parameter of the BucketAccessClass
+	CrossNamespaces []string `json:"crossNamespaces,omitempty" yaml:"crossNamespaces,omitempty" mapstructure:"crossNamespaces,omitempty"`
+
 	// Indicates if the contents of the bucket should be emptied as part of the
 	// deletion process
 	EmptyBucket bool `json:"emptyBucket,omitempty" yaml:"emptyBucket,omitempty" mapstructure:"emptyBucket,omitempty"`
@@ -41,21 +122,180 @@ type Objectscale struct {
 	// Default, unique identifier for the single connection.
 	Id string `json:"id" yaml:"id" mapstructure:"id"`
 
+	// Limits corresponds to the JSON schema field "limits".
+	Limits *Limits `json:"limits,omitempty" yaml:"limits,omitempty" mapstructure:"limits,omitempty"`
+
 	// Endpoint of the ObjectScale VDC Management Internal service
 	MgmtEndpoint string `json:"mgmt-endpoint" yaml:"mgmt-endpoint" mapstructure:"mgmt-endpoint"`
 
 	// Namespace associated with the user/tenant that is allowed to access the bucket
 	Namespace *string `json:"namespace,omitempty" yaml:"namespace,omitempty" mapstructure:"namespace,omitempty"`
 
+	// OidcProvider corresponds to the JSON schema field "oidcProvider".
+	OidcProvider *OidcProvider `json:"oidcProvider,omitempty" yaml:"oidcProvider,omitempty" mapstructure:"oidcProvider,omitempty"`
+
+	// List of named bucket policy statement templates, that can be referenced from
+	// the BucketAccessClass using the 'policyTemplate' parameter
+	PolicyTemplates []PolicyTemplate `json:"policyTemplates,omitempty" yaml:"policyTemplates,omitempty" mapstructure:"policyTemplates,omitempty"`
+
 	// Protocols corresponds to the JSON schema field "protocols".
 	Protocols Protocols `json:"protocols" yaml:"protocols" mapstructure:"protocols"`
 
+	// Reconciliation corresponds to the JSON schema field "reconciliation".
+	Reconciliation *Reconciliation `json:"reconciliation,omitempty" yaml:"reconciliation,omitempty" mapstructure:"reconciliation,omitempty"`
+
 	// Identity and Access Management (IAM) API specific field, points to the region
 	// in which object storage provider is installed
 	Region *string `json:"region,omitempty" yaml:"region,omitempty" mapstructure:"region,omitempty"`
 
+	// Timeouts corresponds to the JSON schema field "timeouts".
+	Timeouts *Timeouts `json:"timeouts,omitempty" yaml:"timeouts,omitempty" mapstructure:"timeouts,omitempty"`
+
 	// Tls corresponds to the JSON schema field "tls".
 	Tls Tls `json:"tls" yaml:"tls" mapstructure:"tls"`
+
+	// Go template of the IAM usernames created for bucket access. Available variables
+	// are .Namespace and .AccessName. Names longer than 64 characters are truncated
+	// with hash suffix. Defaults to '{{ .Namespace }}-user-{{ .AccessName }}'
+	UsernameTemplate *string `json:"usernameTemplate,omitempty" yaml:"usernameTemplate,omitempty" mapstructure:"usernameTemplate,omitempty"`
+}
+
+type ObjectscaleAccessManagement string
+
+var enumValues_ObjectscaleAccessManagement = []interface{}{
+	"bucketPolicy",
+	"group",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ObjectscaleAccessManagement) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_ObjectscaleAccessManagement {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_ObjectscaleAccessManagement, v)
+	}
+	*j = ObjectscaleAccessManagement(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ObjectscaleAccessManagement) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_ObjectscaleAccessManagement {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_ObjectscaleAccessManagement, v)
+	}
+	*j = ObjectscaleAccessManagement(v)
+	return nil
+}
+
+const ObjectscaleAccessManagementBucketPolicy ObjectscaleAccessManagement = "bucketPolicy"
+const ObjectscaleAccessManagementGroup ObjectscaleAccessManagement = "group"
+
+// OpenID Connect provider issuing Kubernetes ServiceAccount tokens, trusted by the
+// IAM roles created for bucket access with IAM authentication
+type OidcProvider struct {
+	// Audience of the ServiceAccount tokens. If set, the role can be assumed only
+	// with tokens issued for this audience
+	Audience *string `json:"audience,omitempty" yaml:"audience,omitempty" mapstructure:"audience,omitempty"`
+
+	// Issuer URL of the ServiceAccount tokens. The provider must be registered in the
+	// ObjectScale IAM of the connection namespace
+	Issuer string `json:"issuer" yaml:"issuer" mapstructure:"issuer"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OidcProvider) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if v, ok := raw["issuer"]; !ok || v == nil {
+		return fmt.Errorf("field issuer in OidcProvider: required")
+	}
+	type Plain OidcProvider
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	*j = OidcProvider(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OidcProvider) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if v, ok := raw["issuer"]; !ok || v == nil {
+		return fmt.Errorf("field issuer in OidcProvider: required")
+	}
+	type Plain OidcProvider
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OidcProvider(plain)
+	return nil
+}
+
+// Named Go template rendering one or more bucket policy statements for a single
+// bucket access
+type PolicyTemplate struct {
+	// Allows the rendered statements to grant the s3:PutBucketPolicy action
+	AllowPutBucketPolicy bool `json:"allowPutBucketPolicy,omitempty" yaml:"allowPutBucketPolicy,omitempty" mapstructure:"allowPutBucketPolicy,omitempty"`
+
+	// Unique name of the template, referenced by the 'policyTemplate' parameter of
+	// the BucketAccessClass
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Go template rendering a JSON policy statement or JSON array of policy
+	// statements. Available variables are .BucketName, .PrincipalARN, .Namespace and
+	// .AccessName
+	Template string `json:"template" yaml:"template" mapstructure:"template"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *PolicyTemplate) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if v, ok := raw["name"]; !ok || v == nil {
+		return fmt.Errorf("field name in PolicyTemplate: required")
+	}
+	if v, ok := raw["template"]; !ok || v == nil {
+		return fmt.Errorf("field template in PolicyTemplate: required")
+	}
+	type Plain PolicyTemplate
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["allowPutBucketPolicy"]; !ok || v == nil {
+		plain.AllowPutBucketPolicy = false
+	}
+	*j = PolicyTemplate(plain)
+	return nil
 }
 
 // Protocols supported by the connection
@@ -64,6 +304,53 @@ type Protocols struct {
 	S3 *S3 `json:"s3,omitempty" yaml:"s3,omitempty" mapstructure:"s3,omitempty"`
 }
 
+// Detection of orphaned IAM users and bucket policy statements, left behind by
+// failed or partially applied operations
+type Reconciliation struct {
+	// Interval of the periodic orphan detection, e.g. '1h'. If not set, orphans are
+	// detected only on demand, with the 'cosictl reconcile' command or the admin API
+	Interval *string `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
+
+	// Removes the detected orphans. By default, orphans are only reported
+	RemoveOrphans bool `json:"removeOrphans,omitempty" yaml:"removeOrphans,omitempty" mapstructure:"removeOrphans,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Reconciliation) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	type Plain Reconciliation
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["removeOrphans"]; !ok || v == nil {
+		plain.RemoveOrphans = false
+	}
+	*j = Reconciliation(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Reconciliation) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain Reconciliation
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw["removeOrphans"]; !ok || v == nil {
+		plain.RemoveOrphans = false
+	}
+	*j = Reconciliation(plain)
+	return nil
+}
+
 // S3 configuration
 type S3 struct {
 	// Endpoint of the ObjectStore S3 service
@@ -88,6 +375,86 @@ func (j *S3) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// Timeouts of the driver operations and of the requests to ObjectScale, in form of
+// Go durations, e.g. '30s'. Zero disables the timeout, leaving only the deadline
+// of the caller
+type Timeouts struct {
+	// Timeout of the bucket creation, including all calls to ObjectScale
+	CreateBucket string `json:"createBucket,omitempty" yaml:"createBucket,omitempty" mapstructure:"createBucket,omitempty"`
+
+	// Timeout of the bucket deletion, including all calls to ObjectScale
+	DeleteBucket string `json:"deleteBucket,omitempty" yaml:"deleteBucket,omitempty" mapstructure:"deleteBucket,omitempty"`
+
+	// Timeout of granting the bucket access, including all calls to ObjectScale
+	GrantBucketAccess string `json:"grantBucketAccess,omitempty" yaml:"grantBucketAccess,omitempty" mapstructure:"grantBucketAccess,omitempty"`
+
+	// Timeout of a single HTTP request to the ObjectScale management and IAM APIs
+	HttpRequest string `json:"httpRequest,omitempty" yaml:"httpRequest,omitempty" mapstructure:"httpRequest,omitempty"`
+
+	// Timeout of revoking the bucket access, including all calls to ObjectScale
+	RevokeBucketAccess string `json:"revokeBucketAccess,omitempty" yaml:"revokeBucketAccess,omitempty" mapstructure:"revokeBucketAccess,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Timeouts) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	type Plain Timeouts
+	var plain Plain
+	if err := json.Unmarshal(b, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["createBucket"]; !ok || v == nil {
+		plain.CreateBucket = "20s"
+	}
+	if v, ok := raw["deleteBucket"]; !ok || v == nil {
+		plain.DeleteBucket = "0"
+	}
+	if v, ok := raw["grantBucketAccess"]; !ok || v == nil {
+		plain.GrantBucketAccess = "0"
+	}
+	if v, ok := raw["httpRequest"]; !ok || v == nil {
+		plain.HttpRequest = "15s"
+	}
+	if v, ok := raw["revokeBucketAccess"]; !ok || v == nil {
+		plain.RevokeBucketAccess = "0"
+	}
+	*j = Timeouts(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Timeouts) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain Timeouts
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw["createBucket"]; !ok || v == nil {
+		plain.CreateBucket = "20s"
+	}
+	if v, ok := raw["deleteBucket"]; !ok || v == nil {
+		plain.DeleteBucket = "0"
+	}
+	if v, ok := raw["grantBucketAccess"]; !ok || v == nil {
+		plain.GrantBucketAccess = "0"
+	}
+	if v, ok := raw["httpRequest"]; !ok || v == nil {
+		plain.HttpRequest = "15s"
+	}
+	if v, ok := raw["revokeBucketAccess"]; !ok || v == nil {
+		plain.RevokeBucketAccess = "0"
+	}
+	*j = Timeouts(plain)
+	return nil
+}
+
 // TLS configuration details
 type Tls struct {
 	// Base64 encoded content of the clients's certificate file
@@ -183,6 +550,9 @@ func (j *Objectscale) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(b, &plain); err != nil {
 		return err
 	}
+	if v, ok := raw["accessManagement"]; !ok || v == nil {
+		plain.AccessManagement = "bucketPolicy"
+	}
 	if v, ok := raw["emptyBucket"]; !ok || v == nil {
 		plain.EmptyBucket = false
 	}
@@ -216,6 +586,9 @@ func (j *Objectscale) UnmarshalYAML(value *yaml.Node) error {
 	if err := value.Decode(&plain); err != nil {
 		return err
 	}
+	if v, ok := raw["accessManagement"]; !ok || v == nil {
+		plain.AccessManagement = "bucketPolicy"
+	}
 	if v, ok := raw["emptyBucket"]; !ok || v == nil {
 		plain.EmptyBucket = false
 	}
@@ -264,3 +637,27 @@ func (j *Credentials) UnmarshalJSON(b []byte) error {
 	*j = Credentials(plain)
 	return nil
 }
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *PolicyTemplate) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if v, ok := raw["name"]; !ok || v == nil {
+		return fmt.Errorf("field name in PolicyTemplate: required")
+	}
+	if v, ok := raw["template"]; !ok || v == nil {
+		return fmt.Errorf("field template in PolicyTemplate: required")
+	}
+	type Plain PolicyTemplate
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw["allowPutBucketPolicy"]; !ok || v == nil {
+		plain.AllowPutBucketPolicy = false
+	}
+	*j = PolicyTemplate(plain)
+	return nil
+}
@@ -27,7 +27,7 @@ var log = csmlog.GetLogger()
 //go:generate go run github.com/atombender/go-jsonschema/cmd/gojsonschema@v0.13.1 --package=config --output=config.gen.go config.schema.json --extra-imports
 
 // New takes filename and returns populated configuration struct.
-func New(filename string) (*ConfigSchemaJson, error) {
+func New(filename string, opts ...Option) (*ConfigSchemaJson, error) {
 	ext := path.Ext(filename)
 	switch ext {
 	case ".json":
@@ -36,22 +36,43 @@ func New(filename string) (*ConfigSchemaJson, error) {
 			return nil, fmt.Errorf("unable to read config file: %w", err)
 		}
 
-		return NewJSON(b)
+		return NewJSON(b, opts...)
 	case ".yaml", ".yml":
 		b, err := readFile(filename)
 		if err != nil {
 			return nil, fmt.Errorf("unable to read config file: %w", err)
 		}
 
-		return NewYAML(b)
+		return NewYAML(b, opts...)
 	default:
 		return nil, errors.New("invalid file extension, should be .json, .yaml or .yml")
 	}
 }
 
 // NewJSON takes array of bytes and unmarshals it, to return populated configuration struct.
-// Array of bytes is expected to be in JSON format.
-func NewJSON(bytes []byte) (*ConfigSchemaJson, error) {
+// Array of bytes is expected to be in JSON format. It is validated against the config schema first,
+// so all violations of the schema are reported at once, as ValidationErrors.
+func NewJSON(bytes []byte, opts ...Option) (*ConfigSchemaJson, error) {
+	if err := validate(bytes, opts...); err != nil {
+		return nil, err
+	}
+
+	return unmarshalJSON(bytes)
+}
+
+// validate validates the config against the config schema, and logs the warnings.
+func validate(bytes []byte, opts ...Option) error {
+	warnings, err := Validate(bytes, opts...)
+
+	for _, warning := range warnings {
+		log.Warnf("config: %v; unknown properties will be rejected in a future release", warning)
+	}
+
+	return err
+}
+
+// unmarshalJSON unmarshals the config, which was already validated against the config schema.
+func unmarshalJSON(bytes []byte) (*ConfigSchemaJson, error) {
 	cfg := &ConfigSchemaJson{}
 
 	err := json.Unmarshal(bytes, cfg)
@@ -65,10 +86,15 @@ func NewJSON(bytes []byte) (*ConfigSchemaJson, error) {
 }
 
 // NewYAML takes array of bytes and unmarshals it, to return populated configuration struct.
-// Array of bytes is expected to be in YAML format.
-func NewYAML(bytes []byte) (*ConfigSchemaJson, error) {
+// Array of bytes is expected to be in YAML format. It is validated against the config schema first,
+// so all violations of the schema are reported at once, as ValidationErrors.
+func NewYAML(bytes []byte, opts ...Option) (*ConfigSchemaJson, error) {
+	if err := validate(bytes, opts...); err != nil {
+		return nil, err
+	}
+
 	// we need to unmarshall it into simple map[string]interface{}
-	// config structure does not have custom UnmarshallYAML fields, so the validation is not performed
+	// config structure does not have custom UnmarshallYAML fields, so it is converted to JSON
 	var body map[string]interface{}
 
 	err := yaml.Unmarshal(bytes, &body)
@@ -81,8 +107,8 @@ func NewYAML(bytes []byte) (*ConfigSchemaJson, error) {
 	// and there is no case, when the Marshaling will fail.
 	b, _ := json.Marshal(body)
 
-	// after we marshalled it to JSON, we need to call NewJSON func
-	return NewJSON(b)
+	// after we marshalled it to JSON, we can unmarshal it to the config structure
+	return unmarshalJSON(b)
 }
 
 func readFile(filename string) ([]byte, error) {
@@ -22,6 +22,8 @@ var (
 	invalidObject     = regexp.MustCompile(`^json: cannot unmarshal (.+) into Go value of type (.+)$`)
 	invalidObjectYAML = regexp.MustCompile(`cannot unmarshal (.+) (.+) into map`)
 	invalidField      = regexp.MustCompile(`^json: cannot unmarshal (.+) into Go struct field (.+) of type (.+)$`)
+	invalidEnumValue  = regexp.MustCompile(`^invalid value \(expected one of (.+)\): (.+)$`)
+	invalidEnumType   = regexp.MustCompile(`^json: cannot unmarshal (.+) into Go value of type string$`)
 )
 
 func TestObjectscaleUnmarshalJSON(t *testing.T) {
@@ -667,3 +669,662 @@ func TestConfigSchemaJsonUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyTemplateUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "valid policy template",
+			data: []byte(`{"name":"read-only","template":"{}","allowPutBucketPolicy":true}`),
+			fail: false,
+		},
+		{
+			name:         "missing name",
+			data:         []byte(`{"template":"{}"}`),
+			fail:         true,
+			errorMessage: missingField,
+		},
+		{
+			name:         "missing template",
+			data:         []byte(`{"name":"read-only"}`),
+			fail:         true,
+			errorMessage: missingField,
+		},
+		{
+			name:         "invalid allowPutBucketPolicy",
+			data:         []byte(`{"name":"read-only","template":"{}","allowPutBucketPolicy":"yes"}`),
+			fail:         true,
+			errorMessage: invalidField,
+		},
+		{
+			name:         "unmarshall error",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObject,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var policyTemplate PolicyTemplate
+
+			err := policyTemplate.UnmarshalJSON(tc.data)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPolicyTemplateUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "valid policy template",
+			data: []byte(`name: read-only
+template: "{}"`),
+			fail: false,
+		},
+		{
+			name:         "missing name",
+			data:         []byte(`template: "{}"`),
+			fail:         true,
+			errorMessage: missingField,
+		},
+		{
+			name:         "missing template",
+			data:         []byte(`name: read-only`),
+			fail:         true,
+			errorMessage: missingField,
+		},
+		{
+			name:         "unmarshall error",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObjectYAML,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var policyTemplate PolicyTemplate
+			var node yaml.Node
+
+			err := yaml.Unmarshal(tc.data, &node)
+			if err != nil {
+				log.Fatalf("Error unmarshaling YAML: %v", err)
+			}
+			err = policyTemplate.UnmarshalYAML(&node)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestObjectscaleAccessManagementUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		want         ObjectscaleAccessManagement
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "bucket policy",
+			data: []byte(`"bucketPolicy"`),
+			want: ObjectscaleAccessManagementBucketPolicy,
+		},
+		{
+			name: "group",
+			data: []byte(`"group"`),
+			want: ObjectscaleAccessManagementGroup,
+		},
+		{
+			name:         "invalid value",
+			data:         []byte(`"user"`),
+			fail:         true,
+			errorMessage: invalidEnumValue,
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`{}`),
+			fail:         true,
+			errorMessage: invalidEnumType,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var accessManagement ObjectscaleAccessManagement
+
+			err := accessManagement.UnmarshalJSON(tc.data)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, accessManagement)
+			}
+		})
+	}
+}
+
+func TestObjectscaleAccessManagementUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		want         ObjectscaleAccessManagement
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "group",
+			data: []byte(`group`),
+			want: ObjectscaleAccessManagementGroup,
+		},
+		{
+			name:         "invalid value",
+			data:         []byte(`user`),
+			fail:         true,
+			errorMessage: invalidEnumValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var accessManagement ObjectscaleAccessManagement
+			var node yaml.Node
+
+			err := yaml.Unmarshal(tc.data, &node)
+			if err != nil {
+				log.Fatalf("Error unmarshaling YAML: %v", err)
+			}
+			err = accessManagement.UnmarshalYAML(node.Content[0])
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, accessManagement)
+			}
+		})
+	}
+}
+
+func TestOidcProviderUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "valid issuer",
+			data: []byte(`{"issuer":"https://oidc.example.com"}`),
+			fail: false,
+		},
+		{
+			name: "valid issuer and audience",
+			data: []byte(`{"issuer":"https://oidc.example.com","audience":"objectscale"}`),
+			fail: false,
+		},
+		{
+			name:         "empty value",
+			data:         []byte(`{}`),
+			fail:         true,
+			errorMessage: missingField,
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObject,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var provider OidcProvider
+
+			err := provider.UnmarshalJSON(tc.data)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOidcProviderUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "valid issuer",
+			data: []byte(`issuer: https://oidc.example.com`),
+			fail: false,
+		},
+		{
+			name:         "empty value",
+			data:         []byte(`issuer: `),
+			fail:         true,
+			errorMessage: missingField,
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObjectYAML,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var provider OidcProvider
+			var node yaml.Node
+
+			err := yaml.Unmarshal(tc.data, &node)
+			if err != nil {
+				log.Fatalf("Error unmarshaling YAML: %v", err)
+			}
+			err = provider.UnmarshalYAML(&node)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReconciliationUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		fail          bool
+		errorMessage  *regexp.Regexp
+		removeOrphans bool
+	}{
+		{
+			name: "empty value",
+			data: []byte(`{}`),
+			fail: false,
+		},
+		{
+			name:          "interval and removal",
+			data:          []byte(`{"interval":"1h","removeOrphans":true}`),
+			fail:          false,
+			removeOrphans: true,
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObject,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var reconciliation Reconciliation
+
+			err := reconciliation.UnmarshalJSON(tc.data)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.removeOrphans, reconciliation.RemoveOrphans)
+			}
+		})
+	}
+}
+
+func TestReconciliationUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		fail          bool
+		errorMessage  *regexp.Regexp
+		removeOrphans bool
+	}{
+		{
+			name: "interval only",
+			data: []byte(`interval: 1h`),
+			fail: false,
+		},
+		{
+			name:          "removal",
+			data:          []byte(`removeOrphans: true`),
+			fail:          false,
+			removeOrphans: true,
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObjectYAML,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var reconciliation Reconciliation
+			var node yaml.Node
+
+			err := yaml.Unmarshal(tc.data, &node)
+			if err != nil {
+				log.Fatalf("Error unmarshaling YAML: %v", err)
+			}
+			err = reconciliation.UnmarshalYAML(&node)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.removeOrphans, reconciliation.RemoveOrphans)
+			}
+		})
+	}
+}
+
+func TestTimeoutsUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+		createBucket string
+		httpRequest  string
+	}{
+		{
+			name:         "empty value",
+			data:         []byte(`{}`),
+			fail:         false,
+			createBucket: "20s",
+			httpRequest:  "15s",
+		},
+		{
+			name:         "custom values",
+			data:         []byte(`{"createBucket":"1m","httpRequest":"30s"}`),
+			fail:         false,
+			createBucket: "1m",
+			httpRequest:  "30s",
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObject,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var timeouts Timeouts
+
+			err := timeouts.UnmarshalJSON(tc.data)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.createBucket, timeouts.CreateBucket)
+				assert.Equal(t, "0", timeouts.DeleteBucket)
+				assert.Equal(t, tc.httpRequest, timeouts.HttpRequest)
+			}
+		})
+	}
+}
+
+func TestTimeoutsUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+		createBucket string
+		httpRequest  string
+	}{
+		{
+			name:         "operation timeout",
+			data:         []byte(`createBucket: 1m`),
+			fail:         false,
+			createBucket: "1m",
+			httpRequest:  "15s",
+		},
+		{
+			name:         "http request timeout",
+			data:         []byte(`httpRequest: 30s`),
+			fail:         false,
+			createBucket: "20s",
+			httpRequest:  "30s",
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObjectYAML,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var timeouts Timeouts
+			var node yaml.Node
+
+			err := yaml.Unmarshal(tc.data, &node)
+			if err != nil {
+				log.Fatalf("Error unmarshaling YAML: %v", err)
+			}
+			err = timeouts.UnmarshalYAML(&node)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.createBucket, timeouts.CreateBucket)
+				assert.Equal(t, tc.httpRequest, timeouts.HttpRequest)
+			}
+		})
+	}
+}
+
+func TestLimitsUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+		expected     Limits
+	}{
+		{
+			name:     "empty value",
+			data:     []byte(`{}`),
+			fail:     false,
+			expected: Limits{},
+		},
+		{
+			name: "all limits",
+			data: []byte(`{"maxInFlight":10,"requestsPerSecond":2.5,"burst":5}`),
+			fail: false,
+			expected: Limits{
+				MaxInFlight:       func() *int { i := 10; return &i }(),
+				RequestsPerSecond: func() *float64 { f := 2.5; return &f }(),
+				Burst:             func() *int { i := 5; return &i }(),
+			},
+		},
+		{
+			name:         "zero max in-flight",
+			data:         []byte(`{"maxInFlight":0}`),
+			fail:         true,
+			errorMessage: regexp.MustCompile("^field maxInFlight: must be >= 1$"),
+		},
+		{
+			name:         "zero requests per second",
+			data:         []byte(`{"requestsPerSecond":0}`),
+			fail:         true,
+			errorMessage: regexp.MustCompile("^field requestsPerSecond: must be > 0$"),
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: invalidObject,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var limits Limits
+
+			err := limits.UnmarshalJSON(tc.data)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, limits)
+			}
+		})
+	}
+}
+
+func TestLimitsUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		data         []byte
+		fail         bool
+		errorMessage *regexp.Regexp
+	}{
+		{
+			name: "max in-flight",
+			data: []byte(`maxInFlight: 10`),
+			fail: false,
+		},
+		{
+			name:         "negative burst",
+			data:         []byte(`burst: -1`),
+			fail:         true,
+			errorMessage: regexp.MustCompile("^field burst: must be >= 1$"),
+		},
+		{
+			name:         "invalid type",
+			data:         []byte(`""`),
+			fail:         true,
+			errorMessage: regexp.MustCompile("cannot unmarshal (.+) into config.Plain"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var limits Limits
+			var node yaml.Node
+
+			err := yaml.Unmarshal(tc.data, &node)
+			if err != nil {
+				log.Fatalf("Error unmarshaling YAML: %v", err)
+			}
+			err = limits.UnmarshalYAML(&node)
+			if tc.fail {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tc.errorMessage, err.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
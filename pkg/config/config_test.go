@@ -20,6 +20,7 @@ import (
 var (
 	missingFile      = regexp.MustCompile(`^unable to read config file`)
 	invalidExtension = regexp.MustCompile(`^invalid file extension, should be .json, .yaml or .yml$`)
+	missingProperty  = regexp.MustCompile(`missing required property`)
 
 	validJSON = `{
     "connections": [
@@ -35,7 +36,7 @@ var (
                 "emptyBucket": false,
                 "protocols": {
                     "s3": {
-                        "endpoint": "test.endpoint"
+                        "endpoint": "https://s3.example.com"
                     }
                 },
                 "tls": {
@@ -57,7 +58,7 @@ var (
                 "namespace": "testnamespace",
                 "protocols": {
                     "s3": {
-                        "endpoint": "test.endpoint"
+                        "endpoint": "https://s3.example.com"
                     }
                 },
                 "tls": {
@@ -79,7 +80,7 @@ var (
     emptyBucket: false
     protocols:
       s3:
-        endpoint: test.endpoint
+        endpoint: https://s3.example.com
     tls:
       insecure: true`
 
@@ -92,7 +93,7 @@ var (
     emptyBucket: false
     protocols:
       s3:
-        endpoint: test.endpoint
+        endpoint: https://s3.example.com
     tls:
       insecure: true`
 )
@@ -125,7 +126,7 @@ func TestNew(t *testing.T) {
 				content: invalidJSON,
 			},
 			fail:         true,
-			errorMessage: missingField,
+			errorMessage: missingProperty,
 		},
 		{
 			name: "valid YAML",
@@ -150,7 +151,7 @@ func TestNew(t *testing.T) {
 				content: invalidYAML,
 			},
 			fail:         true,
-			errorMessage: missingField,
+			errorMessage: missingProperty,
 		},
 		{
 			name: "invalid YML",
@@ -159,7 +160,7 @@ func TestNew(t *testing.T) {
 				content: invalidYAML,
 			},
 			fail:         true,
-			errorMessage: missingField,
+			errorMessage: missingProperty,
 		},
 		{
 			name: "missing JSON file",
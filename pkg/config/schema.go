@@ -0,0 +1,408 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package config
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaDocument is the JSON schema of the config, which the config structures are generated from.
+//
+//go:embed config.schema.json
+var schemaDocument []byte
+
+// schema is the subset of JSON schema keywords used by the config schema.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Items                *schema            `json:"items"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Enum                 []string           `json:"enum"`
+	Format               string             `json:"format"`
+	Minimum              *float64           `json:"minimum"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+// ValidationError is a violation of the config schema, located in the config document.
+type ValidationError struct {
+	// Path is the JSON path of the invalid value, e.g. $.connections[0].objectscale.id.
+	Path string
+	// Line and Column locate the invalid value in the config document, both starting at 1.
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// ValidationErrors are all violations of the config schema found in the config document.
+type ValidationErrors []*ValidationError
+
+// Error implements error interface, listing the violations one per line.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Option configures the validation of the config.
+type Option func(*validator)
+
+// Strict rejects the properties not defined by the schema, so misspelled keys are not ignored.
+// Otherwise, such properties are only reported as warnings, unless the schema disallows them
+// with additionalProperties.
+func Strict(strict bool) Option {
+	return func(v *validator) {
+		v.strict = strict
+	}
+}
+
+// Validate validates the config document in JSON or YAML format against the config schema.
+// The url and byte formats are checked, and IDs of the connections must be unique.
+// Explicit null values are treated as absent, so null is valid for the optional properties.
+// All violations are returned at once as ValidationErrors, in the order of their location in the document.
+// Properties not defined by the schema are returned as warnings, unless the validation is Strict.
+func Validate(bytes []byte, opts ...Option) (ValidationErrors, error) {
+	var root schema
+	if err := json.Unmarshal(schemaDocument, &root); err != nil {
+		return nil, fmt.Errorf("invalid config schema: %w", err)
+	}
+
+	// YAML is a superset of JSON, so both formats are parsed with the locations of the values.
+	var document yaml.Node
+	if err := yaml.Unmarshal(bytes, &document); err != nil {
+		return nil, err
+	}
+
+	// empty document is an empty config
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{definitions: root.Definitions}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	v.validate(document.Content[0], &root, "$")
+	v.validateConnectionIDs(document.Content[0])
+
+	sortByLocation(v.warnings)
+
+	if len(v.errs) == 0 {
+		return v.warnings, nil
+	}
+
+	sortByLocation(v.errs)
+
+	return v.warnings, v.errs
+}
+
+// validator collects the violations of the schema, while walking the config document.
+type validator struct {
+	definitions map[string]*schema
+	strict      bool
+	errs        ValidationErrors
+	warnings    ValidationErrors
+}
+
+func (v *validator) report(node *yaml.Node, path, format string, args ...any) {
+	v.errs = append(v.errs, newValidationError(node, path, format, args...))
+}
+
+func (v *validator) warn(node *yaml.Node, path, format string, args ...any) {
+	v.warnings = append(v.warnings, newValidationError(node, path, format, args...))
+}
+
+func newValidationError(node *yaml.Node, path, format string, args ...any) *ValidationError {
+	return &ValidationError{
+		Path:    path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// sortByLocation sorts the violations in the order of their location in the document.
+func sortByLocation(errs ValidationErrors) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+
+		return errs[i].Column < errs[j].Column
+	})
+}
+
+func (v *validator) validate(node *yaml.Node, s *schema, path string) {
+	node = resolveAlias(node)
+
+	// null is the same as the absent value, which is checked by the required properties
+	if isNull(node) {
+		return
+	}
+
+	if s.Ref != "" {
+		name, _ := strings.CutPrefix(s.Ref, "#/definitions/")
+
+		definition, ok := v.definitions[name]
+		if !ok {
+			v.report(node, path, "unresolved schema reference %s", s.Ref)
+			return
+		}
+
+		s = definition
+	}
+
+	if got := nodeType(node); !matchesType(got, s.Type) {
+		v.report(node, path, "expected %s, got %s", s.Type, got)
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateObject(node, s, path)
+
+	case yaml.SequenceNode:
+		if s.Items == nil {
+			return
+		}
+
+		for i, item := range node.Content {
+			v.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i))
+		}
+
+	case yaml.ScalarNode:
+		v.validateScalar(node, s, path)
+	}
+}
+
+func (v *validator) validateObject(node *yaml.Node, s *schema, path string) {
+	present := make(map[string]bool, len(node.Content)/2)
+
+	for _, pair := range mappingPairs(node) {
+		key, value := pair[0].Value, pair[1]
+		present[key] = !isNull(value)
+
+		property, ok := s.Properties[key]
+		if !ok {
+			v.unknownProperty(pair[0], s, path+"."+key)
+			continue
+		}
+
+		v.validate(value, property, path+"."+key)
+	}
+
+	for _, name := range s.Required {
+		if !present[name] {
+			v.report(node, path, "missing required property %s", name)
+		}
+	}
+}
+
+// unknownProperty reports the property not defined by the schema. Such properties are allowed by JSON schema,
+// unless additionalProperties is false, but are likely misspelled keys, so they are reported as warnings,
+// or as errors in the strict validation.
+func (v *validator) unknownProperty(key *yaml.Node, s *schema, path string) {
+	switch {
+	case s.AdditionalProperties != nil && *s.AdditionalProperties:
+		return
+	case v.strict || s.AdditionalProperties != nil:
+		v.report(key, path, "unknown property %s", key.Value)
+	default:
+		v.warn(key, path, "unknown property %s", key.Value)
+	}
+}
+
+func (v *validator) validateScalar(node *yaml.Node, s *schema, path string) {
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, node.Value) {
+		v.report(node, path, "invalid value %q, expected one of %s", node.Value, strings.Join(s.Enum, ", "))
+	}
+
+	if err := checkFormat(node.Value, s.Format); err != nil {
+		v.report(node, path, "%v", err)
+	}
+
+	if s.Minimum == nil && s.ExclusiveMinimum == nil {
+		return
+	}
+
+	value, err := strconv.ParseFloat(node.Value, 64)
+	if err != nil {
+		return
+	}
+
+	if s.Minimum != nil && value < *s.Minimum {
+		v.report(node, path, "must be >= %v", *s.Minimum)
+	}
+
+	if s.ExclusiveMinimum != nil && value <= *s.ExclusiveMinimum {
+		v.report(node, path, "must be > %v", *s.ExclusiveMinimum)
+	}
+}
+
+// validateConnectionIDs reports the connections with the same ID, as they could not be told apart
+// by the BucketClasses and BucketAccessClasses referencing them.
+func (v *validator) validateConnectionIDs(root *yaml.Node) {
+	connections := mappingValue(root, "connections")
+	if connections == nil || connections.Kind != yaml.SequenceNode {
+		return
+	}
+
+	seen := make(map[string]string, len(connections.Content))
+
+	for i, connection := range connections.Content {
+		id := mappingValue(mappingValue(connection, "objectscale"), "id")
+		if id == nil || id.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		path := fmt.Sprintf("$.connections[%d].objectscale.id", i)
+
+		if first, ok := seen[id.Value]; ok {
+			v.report(id, path, "duplicate connection id %q, already used by %s", id.Value, first)
+			continue
+		}
+
+		seen[id.Value] = path
+	}
+}
+
+// checkFormat checks the string value against the format of the schema. Unknown formats are not checked.
+func checkFormat(value, format string) error {
+	switch format {
+	case "url":
+		u, err := url.Parse(value)
+		if err != nil {
+			return err
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid url %q, expected http or https URL with host", value)
+		}
+
+	case "byte":
+		// the value is not included in the error, as it can be a private key
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("invalid base64 value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the value of the key in the YAML mapping node, or nil if the node is not a mapping
+// or the key is not present.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	var value *yaml.Node
+
+	// keys of the mapping override the merged ones, which precede them
+	for _, pair := range mappingPairs(node) {
+		if pair[0].Value == key {
+			value = pair[1]
+		}
+	}
+
+	return value
+}
+
+// mappingPairs returns the key and value pairs of the YAML mapping node, with the aliases resolved.
+// Pairs of the mappings merged using the '<<' key precede the pairs of the node.
+func mappingPairs(node *yaml.Node) [][2]*yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var merged, pairs [][2]*yaml.Node
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolveAlias(node.Content[i+1])
+
+		if key.ShortTag() != "!!merge" {
+			pairs = append(pairs, [2]*yaml.Node{key, value})
+			continue
+		}
+
+		if value.Kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				merged = append(merged, mappingPairs(item)...)
+			}
+
+			continue
+		}
+
+		merged = append(merged, mappingPairs(value)...)
+	}
+
+	return append(merged, pairs...)
+}
+
+// resolveAlias returns the node referenced by the YAML alias node, or the node itself.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// isNull checks if the YAML node is null, either explicit or empty value.
+func isNull(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+// nodeType returns the JSON schema type of the YAML node.
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch tag := node.ShortTag(); tag {
+	case "!!str":
+		return "string"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!null":
+		return "null"
+	default:
+		return tag
+	}
+}
+
+// matchesType checks if the value of the type is valid for the expected type. Integers are valid numbers.
+func matchesType(got, expected string) bool {
+	return expected == "" || got == expected || (expected == "number" && got == "integer")
+}
@@ -0,0 +1,355 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package config
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	sample, err := os.ReadFile("../../samples/secret.yaml")
+	require.NoError(t, err)
+
+	// placeholder of the sample must be replaced by the user
+	rootCA := base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----"))
+	sample = []byte(strings.ReplaceAll(string(sample), "<base-64-encoded-root-ca>", rootCA))
+
+	testCases := []struct {
+		name     string
+		document string
+		strict   bool
+		errors   []string
+		warnings []string
+	}{
+		{
+			name:     "valid JSON",
+			document: validJSON,
+		},
+		{
+			name:     "valid YAML",
+			document: validYAML,
+		},
+		{
+			name:     "sample",
+			document: string(sample),
+		},
+		{
+			name:     "empty",
+			document: "",
+		},
+		{
+			name:     "invalid JSON",
+			document: invalidJSON,
+			errors: []string{
+				"line 4, column 28: $.connections[0].objectscale: missing required property mgmt-endpoint",
+				"line 5, column 32: $.connections[0].objectscale.credentials: missing required property password",
+			},
+		},
+		{
+			name:     "invalid YAML",
+			document: invalidYAML,
+			errors: []string{
+				"line 3, column 5: $.connections[0].objectscale: missing required property mgmt-endpoint",
+				"line 4, column 7: $.connections[0].objectscale.credentials: missing required property password",
+			},
+		},
+		{
+			name: "invalid values",
+			document: `driverName: 42
+connections:
+- objectscale:
+    id: testid
+    credentials: admin
+    mgmt-endpoint: https://example.com
+    accessManagement: role
+    protocols:
+      s3:
+        endpoint: https://s3.example.com
+    limits:
+      maxInFlight: 0
+      requestsPerSecond: 0
+      burst: many
+    tls:
+      insecure: "true"`,
+			errors: []string{
+				"line 1, column 13: $.driverName: expected string, got integer",
+				"line 5, column 18: $.connections[0].objectscale.credentials: expected object, got string",
+				"line 7, column 23: $.connections[0].objectscale.accessManagement: invalid value \"role\", expected one of bucketPolicy, group",
+				"line 12, column 20: $.connections[0].objectscale.limits.maxInFlight: must be >= 1",
+				"line 13, column 26: $.connections[0].objectscale.limits.requestsPerSecond: must be > 0",
+				"line 14, column 14: $.connections[0].objectscale.limits.burst: expected integer, got string",
+				"line 16, column 17: $.connections[0].objectscale.tls.insecure: expected boolean, got string",
+			},
+		},
+		{
+			name: "unknown properties",
+			document: `driverName: cosi.dellemc.com
+drivername: typo
+connections:
+- objectscale:
+    id: testid
+    credentials:
+      username: testuser
+      password: testpassword
+    mgmt_endpoint: https://example.com
+    mgmt-endpoint: https://example.com
+    emptyBuckets: true
+    protocols:
+      s3:
+        endpoint: https://s3.example.com
+    tls:
+      insecure: true`,
+			warnings: []string{
+				"line 2, column 1: $.drivername: unknown property drivername",
+				"line 9, column 5: $.connections[0].objectscale.mgmt_endpoint: unknown property mgmt_endpoint",
+				"line 11, column 5: $.connections[0].objectscale.emptyBuckets: unknown property emptyBuckets",
+			},
+		},
+		{
+			name:   "unknown properties in strict validation",
+			strict: true,
+			document: `driverName: cosi.dellemc.com
+drivername: typo
+connections:
+- objectscale:
+    id: testid
+    credentials:
+      username: testuser
+      password: testpassword
+    mgmt_endpoint: https://example.com
+    mgmt-endpoint: https://example.com
+    emptyBuckets: true
+    protocols:
+      s3:
+        endpoint: https://s3.example.com
+    tls:
+      insecure: true`,
+			errors: []string{
+				"line 2, column 1: $.drivername: unknown property drivername",
+				"line 9, column 5: $.connections[0].objectscale.mgmt_endpoint: unknown property mgmt_endpoint",
+				"line 11, column 5: $.connections[0].objectscale.emptyBuckets: unknown property emptyBuckets",
+			},
+		},
+		{
+			name: "null values",
+			document: `driverName: null
+connections:
+- objectscale:
+    id: testid
+    credentials:
+    mgmt-endpoint: https://example.com
+    limits: null
+    timeouts: ~
+    protocols:
+      s3:
+        endpoint: https://s3.example.com
+    tls:
+      insecure: true`,
+			errors: []string{
+				"line 4, column 5: $.connections[0].objectscale: missing required property credentials",
+			},
+		},
+		{
+			name: "invalid formats",
+			document: `connections:
+- objectscale:
+    id: testid
+    credentials:
+      username: testuser
+      password: testpassword
+    mgmt-endpoint: gateway.objectscale.test:443
+    oidcProvider:
+      issuer: ftp://oidc.example.com
+    protocols:
+      s3:
+        endpoint: "https://"
+    tls:
+      insecure: false
+      root-cas: <base-64-encoded-root-ca>
+      client-cert: ` + base64.StdEncoding.EncodeToString([]byte("cert")) + `
+      client-key: "not base64!"`,
+			errors: []string{
+				"line 7, column 20: $.connections[0].objectscale.mgmt-endpoint: invalid url \"gateway.objectscale.test:443\", expected http or https URL with host",
+				"line 9, column 15: $.connections[0].objectscale.oidcProvider.issuer: invalid url \"ftp://oidc.example.com\", expected http or https URL with host",
+				"line 12, column 19: $.connections[0].objectscale.protocols.s3.endpoint: invalid url \"https://\", expected http or https URL with host",
+				"line 15, column 17: $.connections[0].objectscale.tls.root-cas: invalid base64 value: illegal base64 data at input byte 0",
+				"line 17, column 19: $.connections[0].objectscale.tls.client-key: invalid base64 value: illegal base64 data at input byte 3",
+			},
+		},
+		{
+			name: "duplicate connection IDs",
+			document: `connections:
+- objectscale: &connection
+    id: first
+    credentials:
+      username: testuser
+      password: testpassword
+    mgmt-endpoint: https://example.com
+    protocols:
+      s3:
+        endpoint: https://s3.example.com
+    tls:
+      insecure: true
+- objectscale:
+    <<: *connection
+- objectscale: *connection`,
+			errors: []string{
+				"line 3, column 9: $.connections[1].objectscale.id: duplicate connection id \"first\", already used by $.connections[0].objectscale.id",
+				"line 3, column 9: $.connections[2].objectscale.id: duplicate connection id \"first\", already used by $.connections[0].objectscale.id",
+			},
+		},
+		{
+			name:     "invalid connections",
+			document: `connections: {}`,
+			errors: []string{
+				"line 1, column 14: $.connections: expected array, got object",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := Validate([]byte(tc.document), Strict(tc.strict))
+			assert.Equal(t, tc.warnings, messages(warnings))
+
+			if len(tc.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tc.errors, messages(errs))
+		})
+	}
+}
+
+func TestValidateAdditionalProperties(t *testing.T) {
+	t.Parallel()
+
+	document := "known: value\nunknown: value\n"
+	allowed, disallowed := true, false
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(document), &node))
+
+	for name, tc := range map[string]struct {
+		additionalProperties *bool
+		strict               bool
+		errors               []string
+		warnings             []string
+	}{
+		"default": {
+			warnings: []string{"line 2, column 1: $.unknown: unknown property unknown"},
+		},
+		"default in strict validation": {
+			strict: true,
+			errors: []string{"line 2, column 1: $.unknown: unknown property unknown"},
+		},
+		"allowed": {
+			additionalProperties: &allowed,
+			strict:               true,
+		},
+		"disallowed": {
+			additionalProperties: &disallowed,
+			errors:               []string{"line 2, column 1: $.unknown: unknown property unknown"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &schema{
+				Type:                 "object",
+				Properties:           map[string]*schema{"known": {Type: "string"}},
+				AdditionalProperties: tc.additionalProperties,
+			}
+
+			v := &validator{strict: tc.strict}
+			v.validate(node.Content[0], s, "$")
+
+			assert.Equal(t, tc.errors, messages(v.errs))
+			assert.Equal(t, tc.warnings, messages(v.warnings))
+		})
+	}
+}
+
+// messages returns the messages of the violations, or nil if there are none.
+func messages(errs ValidationErrors) []string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return messages
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	_, err := Validate([]byte("connections:\n- objectscale: [\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestNewValidates(t *testing.T) {
+	t.Parallel()
+
+	// tabs are not allowed for indentation of YAML, but are valid whitespace in JSON
+	cfg, err := NewJSON([]byte(strings.ReplaceAll(validJSON, "    ", "\t")))
+	require.NoError(t, err)
+	assert.Len(t, cfg.Connections, 1)
+
+	for name, tc := range map[string]struct {
+		newFunc    func([]byte, ...Option) (*ConfigSchemaJson, error)
+		misspelled string
+		violation  string
+	}{
+		"JSON": {
+			newFunc:    NewJSON,
+			misspelled: strings.Replace(validJSON, `"emptyBucket":`, `"emptyBuckets":`, 1),
+			violation:  "line 12, column 17: $.connections[0].objectscale.emptyBuckets: unknown property emptyBuckets",
+		},
+		"YAML": {
+			newFunc:    NewYAML,
+			misspelled: strings.Replace(validYAML, "emptyBucket:", "emptyBuckets:", 1),
+			violation:  "line 9, column 5: $.connections[0].objectscale.emptyBuckets: unknown property emptyBuckets",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// unknown properties are only logged by default, so the existing configs are still loaded
+			cfg, err := tc.newFunc([]byte(tc.misspelled))
+			require.NoError(t, err)
+			assert.Len(t, cfg.Connections, 1)
+
+			_, err = tc.newFunc([]byte(tc.misspelled), Strict(true))
+
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.EqualError(t, errs, tc.violation)
+		})
+	}
+
+	// optional sections can be explicitly null
+	cfg, err = NewYAML([]byte(validYAML + "\n    limits: null\n    timeouts: ~"))
+	require.NoError(t, err)
+	require.Len(t, cfg.Connections, 1)
+	assert.Nil(t, cfg.Connections[0].Objectscale.Limits)
+	assert.Nil(t, cfg.Connections[0].Objectscale.Timeouts)
+}
@@ -0,0 +1,195 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+const (
+	// AdminPathPrefix is the HTTP path prefix of the admin API.
+	AdminPathPrefix = "/admin/v1"
+
+	// adminRequestTimeout limits the calls to the object storage platform made by a single admin request.
+	adminRequestTimeout = 30 * time.Second
+)
+
+// adminError is the body of the failed admin API response.
+type adminError struct {
+	Error string `json:"error"`
+}
+
+// ServeAdmin serves the read-only admin API over HTTP on the listener, until the context is canceled.
+// Requests must carry the token in the Authorization header, as a bearer token.
+// The API is served without TLS, so the listener must be a UNIX socket or a loopback TCP address,
+// which do not expose the token to the network.
+func (s *Driver) ServeAdmin(ctx context.Context, listener net.Listener, token string) error {
+	if token == "" {
+		return errors.New("admin token must not be empty")
+	}
+
+	if !isLocal(listener.Addr()) {
+		return fmt.Errorf("admin API must be served on UNIX socket or loopback address, not %s", listener.Addr())
+	}
+
+	log.Infof("Serving admin API on %s%s", listener.Addr(), AdminPathPrefix)
+	serveHTTP(ctx, listener, s.adminHandler(token))
+
+	return nil
+}
+
+// isLocal checks if the address is reachable only from the host.
+func isLocal(addr net.Addr) bool {
+	switch addr := addr.(type) {
+	case *net.UnixAddr:
+		return true
+	case *net.TCPAddr:
+		return addr.IP.IsLoopback()
+	default:
+		return false
+	}
+}
+
+// adminHandler routes the admin API requests, after they are authorized with the token.
+func (s *Driver) adminHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+AdminPathPrefix+"/backends", s.listBackends)
+	mux.HandleFunc("GET "+AdminPathPrefix+"/backends/{id}/buckets", s.listBuckets)
+	mux.HandleFunc("GET "+AdminPathPrefix+"/backends/{id}/buckets/{bucket}/policy", s.getBucketPolicy)
+	mux.HandleFunc("GET "+AdminPathPrefix+"/backends/{id}/users", s.listUsers)
+	mux.HandleFunc("GET "+AdminPathPrefix+"/backends/{id}/orphans", s.listOrphans)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			log.WithFields(csmlog.Fields{"path": r.URL.Path, "remote": r.RemoteAddr}).Warn("Unauthorized admin API request")
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, adminError{Error: "unauthorized"})
+
+			return
+		}
+
+		log.WithFields(csmlog.Fields{"method": r.Method, "path": r.URL.Path, "remote": r.RemoteAddr}).Info("Admin API request")
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// listBackends lists the object storage platforms configured for the driver.
+func (s *Driver) listBackends(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.Info().Backends)
+}
+
+// listBuckets lists the buckets in the namespace of the backend.
+func (s *Driver) listBuckets(w http.ResponseWriter, r *http.Request) {
+	inspect(w, r, s, "inspected", func(ctx context.Context, inspector virtualdriver.Inspector) (any, error) {
+		return inspector.ListBuckets(ctx)
+	})
+}
+
+// getBucketPolicy shows the decoded policy of the bucket.
+func (s *Driver) getBucketPolicy(w http.ResponseWriter, r *http.Request) {
+	inspect(w, r, s, "inspected", func(ctx context.Context, inspector virtualdriver.Inspector) (any, error) {
+		return inspector.GetBucketPolicy(ctx, r.PathValue("bucket"))
+	})
+}
+
+// listUsers lists the IAM users created by the driver, with their access keys.
+func (s *Driver) listUsers(w http.ResponseWriter, r *http.Request) {
+	inspect(w, r, s, "inspected", func(ctx context.Context, inspector virtualdriver.Inspector) (any, error) {
+		return inspector.ListUsers(ctx)
+	})
+}
+
+// listOrphans reports the orphans left behind by failed or partially applied operations, without removing them,
+// so they can be reviewed before the removal.
+func (s *Driver) listOrphans(w http.ResponseWriter, r *http.Request) {
+	inspect(w, r, s, "reconciled", func(ctx context.Context, reconciler virtualdriver.Reconciler) (any, error) {
+		return reconciler.Reconcile(ctx, false)
+	})
+}
+
+// inspect runs the query against the backend from the request path, which must implement the optional
+// interface T of the drivers, and writes its result.
+func inspect[T any](w http.ResponseWriter, r *http.Request, s *Driver, action string, query func(context.Context, T) (any, error)) {
+	id := r.PathValue("id")
+
+	var d virtualdriver.Driver
+	if s.drivers != nil {
+		d, _ = s.drivers.Get(id)
+	}
+
+	if d == nil {
+		writeJSON(w, http.StatusNotFound, adminError{Error: "backend " + id + " not found"})
+		return
+	}
+
+	target, ok := d.(T)
+	if !ok {
+		writeJSON(w, http.StatusNotImplemented, adminError{Error: "backend " + id + " cannot be " + action})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), adminRequestTimeout)
+	defer cancel()
+
+	result, err := query(ctx, target)
+	if err != nil {
+		writeJSON(w, httpStatus(status.Code(err)), adminError{Error: status.Convert(err).Message()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// httpStatus maps the gRPC code of the failed query to the HTTP status of the response.
+// Failures of the object storage platform are reported as failures of the upstream server,
+// except the denied access of the driver, which is reported as forbidden.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.PermissionDenied, codes.Unauthenticated:
+		// the driver is denied by the object storage platform, the admin token was already accepted
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes the value as JSON response with the status.
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dell/cosi/pkg/provisioner"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+)
+
+const testAdminToken = "secret-token"
+
+// inspectedDriver is a fake driver, which lets the operators inspect its resources.
+type inspectedDriver struct {
+	fake.Driver
+	err error
+}
+
+func (d *inspectedDriver) ListBuckets(context.Context) ([]string, error) {
+	return []string{"bucket-a", "bucket-b"}, d.err
+}
+
+func (d *inspectedDriver) GetBucketPolicy(_ context.Context, bucketName string) (policy.Document, error) {
+	if bucketName != "bucket-a" {
+		return policy.Document{}, status.Error(codes.NotFound, "bucket not found")
+	}
+
+	return policy.Document{
+		Version:   "2012-10-17",
+		Statement: []policy.StatementEntry{{Effect: policy.EffectAllow, Action: []string{"s3:GetObject"}, Resource: []string{"arn:aws:s3:::bucket-a/*"}}},
+	}, d.err
+}
+
+func (d *inspectedDriver) ListUsers(context.Context) ([]virtualdriver.User, error) {
+	return []virtualdriver.User{{
+		Namespace:  "namespace",
+		Name:       "user",
+		AccessKeys: []virtualdriver.AccessKey{{ID: "key", Status: "Active"}},
+	}}, d.err
+}
+
+// Reconcile reports the orphan, which would be removed on request.
+func (d *inspectedDriver) Reconcile(_ context.Context, remove bool) (*virtualdriver.OrphanReport, error) {
+	return &virtualdriver.OrphanReport{
+		Users:   []virtualdriver.OrphanUser{{Namespace: "namespace", Name: "orphan"}},
+		Removed: remove,
+	}, d.err
+}
+
+// adminGet sends the GET request to the admin API with the token, and decodes the response to the value.
+func adminGet(t *testing.T, server *httptest.Server, path, token string, v any) int {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+AdminPathPrefix+path, nil)
+	require.NoError(t, err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	if v != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+
+	return resp.StatusCode
+}
+
+func TestAdminAPI(t *testing.T) {
+	driverset := &provisioner.Driverset{}
+	require.NoError(t, driverset.Add(&inspectedDriver{Driver: fake.Driver{FakeID: "inspected"}}))
+	require.NoError(t, driverset.Add(&inspectedDriver{Driver: fake.Driver{FakeID: "broken"}, err: status.Error(codes.Unavailable, "backend unavailable")}))
+	require.NoError(t, driverset.Add(&fake.Driver{FakeID: "basic"}))
+
+	driver := &Driver{name: "cosi.dellemc.com", drivers: driverset}
+
+	server := httptest.NewServer(driver.adminHandler(testAdminToken))
+	defer server.Close()
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"MissingToken": func(t *testing.T) {
+			var body adminError
+			assert.Equal(t, http.StatusUnauthorized, adminGet(t, server, "/backends", "", &body))
+			assert.Equal(t, "unauthorized", body.Error)
+		},
+		"InvalidToken": func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, adminGet(t, server, "/backends", "other-token", nil))
+		},
+		"ListBackends": func(t *testing.T) {
+			var backends []BackendInfo
+			require.Equal(t, http.StatusOK, adminGet(t, server, "/backends", testAdminToken, &backends))
+			assert.Equal(t, []BackendInfo{{ID: "basic"}, {ID: "broken"}, {ID: "inspected"}}, backends)
+		},
+		"ListBuckets": func(t *testing.T) {
+			var buckets []string
+			require.Equal(t, http.StatusOK, adminGet(t, server, "/backends/inspected/buckets", testAdminToken, &buckets))
+			assert.Equal(t, []string{"bucket-a", "bucket-b"}, buckets)
+		},
+		"GetBucketPolicy": func(t *testing.T) {
+			var document policy.Document
+			require.Equal(t, http.StatusOK, adminGet(t, server, "/backends/inspected/buckets/bucket-a/policy", testAdminToken, &document))
+			assert.Equal(t, []string{"s3:GetObject"}, document.Statement[0].Action)
+		},
+		"ListUsers": func(t *testing.T) {
+			var users []virtualdriver.User
+			require.Equal(t, http.StatusOK, adminGet(t, server, "/backends/inspected/users", testAdminToken, &users))
+			require.Len(t, users, 1)
+			assert.Equal(t, "user", users[0].Name)
+			assert.Equal(t, "key", users[0].AccessKeys[0].ID)
+		},
+		"ListOrphans": func(t *testing.T) {
+			var report virtualdriver.OrphanReport
+			require.Equal(t, http.StatusOK, adminGet(t, server, "/backends/inspected/orphans", testAdminToken, &report))
+			assert.Equal(t, []virtualdriver.OrphanUser{{Namespace: "namespace", Name: "orphan"}}, report.Users)
+			// orphans are never removed by the read-only admin API
+			assert.False(t, report.Removed)
+		},
+		"BackendNotReconciled": func(t *testing.T) {
+			var body adminError
+			assert.Equal(t, http.StatusNotImplemented, adminGet(t, server, "/backends/basic/orphans", testAdminToken, &body))
+			assert.Equal(t, "backend basic cannot be reconciled", body.Error)
+		},
+		"BucketNotFound": func(t *testing.T) {
+			var body adminError
+			assert.Equal(t, http.StatusNotFound, adminGet(t, server, "/backends/inspected/buckets/missing/policy", testAdminToken, &body))
+			assert.Equal(t, "bucket not found", body.Error)
+		},
+		"BackendNotFound": func(t *testing.T) {
+			assert.Equal(t, http.StatusNotFound, adminGet(t, server, "/backends/missing/buckets", testAdminToken, nil))
+		},
+		"BackendNotInspectable": func(t *testing.T) {
+			assert.Equal(t, http.StatusNotImplemented, adminGet(t, server, "/backends/basic/users", testAdminToken, nil))
+		},
+		"BackendFailure": func(t *testing.T) {
+			var body adminError
+			assert.Equal(t, http.StatusServiceUnavailable, adminGet(t, server, "/backends/broken/users", testAdminToken, &body))
+			assert.Equal(t, "backend unavailable", body.Error)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	for code, expected := range map[codes.Code]int{
+		codes.NotFound:          http.StatusNotFound,
+		codes.InvalidArgument:   http.StatusBadRequest,
+		codes.PermissionDenied:  http.StatusForbidden,
+		codes.Unauthenticated:   http.StatusForbidden,
+		codes.ResourceExhausted: http.StatusTooManyRequests,
+		codes.Unavailable:       http.StatusServiceUnavailable,
+		codes.DeadlineExceeded:  http.StatusGatewayTimeout,
+		codes.Internal:          http.StatusInternalServerError,
+	} {
+		assert.Equal(t, expected, httpStatus(code), code.String())
+	}
+}
+
+func TestServeAdmin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	listener, err := Listen("tcp://127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	driver := &Driver{name: "test"}
+
+	// token is required
+	assert.Error(t, driver.ServeAdmin(ctx, listener, ""))
+
+	// the token is not exposed to the network without TLS
+	public, err := Listen("tcp://0.0.0.0:0")
+	require.NoError(t, err)
+	defer public.Close()
+
+	assert.ErrorContains(t, driver.ServeAdmin(ctx, public, testAdminToken), "loopback")
+
+	require.NoError(t, driver.ServeAdmin(ctx, listener, testAdminToken))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+listener.Addr().String()+AdminPathPrefix+"/backends", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -17,8 +17,11 @@ import (
 	"io/fs"
 	"net"
 	"os"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	spec "sigs.k8s.io/container-object-storage-interface/proto"
 
@@ -32,10 +35,14 @@ import (
 const (
 	// COSISocket is a default location of COSI API UNIX socket.
 	COSISocket = "/var/lib/cosi/cosi.sock"
+
+	// DefaultShutdownTimeout is a default time given to in-flight requests to complete during shutdown.
+	DefaultShutdownTimeout = 30 * time.Second
 )
 
 var (
 	NetListenFunc                   func(string, string) (net.Listener, error)               = net.Listen
+	OsRemoveAllFunc                 func(string) error                                       = os.RemoveAll
 	ProvisionerNewVirtualDriverFunc func(config.Configuration) (virtualdriver.Driver, error) = provisioner.NewVirtualDriver
 	log                                                                                      = csmlog.GetLogger()
 )
@@ -46,13 +53,26 @@ type Driver struct {
 	server *grpc.Server
 	// socket listener
 	lis net.Listener
+	// name of the driver, reported by the identity server
+	name string
+	// path of the UNIX socket, empty if the driver listens on TCP
+	socket string
+	// drivers configured for the object storage platforms
+	drivers *provisioner.Driverset
+	// health reports serving status of the driver, and of every object storage platform by its ID
+	health *health.Server
+	// ready is closed when the gRPC server is ready to serve requests
+	ready chan struct{}
+	// done is closed when the gRPC server is stopped
+	done chan struct{}
+	// err is the error which caused the gRPC server to stop, set before done is closed
+	err error
+	// dryRun is set, when the mutating calls to the object storage platforms are not sent
+	dryRun bool
 }
 
-// New creates a new driver for COSI API with identity and provisioner servers.
-func New(config *config.ConfigSchemaJson, socket, name string) (*Driver, error) {
-	// Setup identity server and provisioner server
-	identityServer := identity.New(name)
-
+// NewDriverset creates the drivers for all object storage platform connections from the config.
+func NewDriverset(config *config.ConfigSchemaJson) (*provisioner.Driverset, error) {
 	driverset := &provisioner.Driverset{}
 
 	for _, cfg := range config.Connections {
@@ -71,81 +91,263 @@ func New(config *config.ConfigSchemaJson, socket, name string) (*Driver, error)
 		log.Infof("New configuration successfully applied to object storage %s", driver.ID())
 	}
 
+	return driverset, nil
+}
+
+// New creates a new driver for COSI API with identity and provisioner servers.
+// The address is either a UNIX socket path, unix://<path> or tcp://<host>:<port>.
+// Additional options, e.g. TLS credentials, are passed to the gRPC server.
+func New(config *config.ConfigSchemaJson, address, name string, options ...grpc.ServerOption) (*Driver, error) {
+	network, address, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup identity server and provisioner server
+	identityServer := identity.New(name)
+
+	driverset, err := NewDriverset(config)
+	if err != nil {
+		return nil, err
+	}
+
 	provisionerServer := provisioner.New(driverset)
-	// Some options for gRPC server may be needed.
-	options := []grpc.ServerOption{}
-	// Create new gRPC server.
-	server := grpc.NewServer(options...)
+	healthServer := newHealthServer(driverset, provisionerServer)
+	// Create new gRPC server, with the default options preceding the additional ones.
+	server := grpc.NewServer(append(defaultServerOptions(), options...)...)
 	// Register identity and provisioner servers, so they will handle gRPC requests to the driver.
 	spec.RegisterIdentityServer(server, identityServer)
 	spec.RegisterProvisionerServer(server, provisionerServer)
+	healthpb.RegisterHealthServer(server, healthServer)
 
-	// Remove socket file if it already exists
-	// so we can start a new driver after crash or pod restart
-	if _, err := os.Stat(socket); !errors.Is(err, fs.ErrNotExist) {
-		if err := os.RemoveAll(socket); err != nil {
-			log.Errorf("failed to remove socket: %v", err)
-			os.Exit(1)
+	var socket string
+	if network == "unix" {
+		socket = address
+
+		// Remove socket file if it already exists
+		// so we can start a new driver after crash or pod restart
+		if _, err := os.Stat(socket); !errors.Is(err, fs.ErrNotExist) {
+			if err := OsRemoveAllFunc(socket); err != nil {
+				return nil, fmt.Errorf("failed to remove socket: %w", err)
+			}
 		}
 	}
 
 	// Create shared listener for gRPC server
-	listener, err := NetListenFunc("unix", socket)
+	listener, err := NetListenFunc(network, address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to announce on the local network address: %w", err)
 	}
 
-	return &Driver{server, listener}, nil
+	return &Driver{
+		server:  server,
+		lis:     listener,
+		socket:  socket,
+		name:    name,
+		drivers: driverset,
+		health:  healthServer,
+		ready:   make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
 }
 
-// starts the gRPC server and returns a channel that will be closed when it is ready.
-func (s *Driver) start(_ context.Context) <-chan struct{} {
-	ready := make(chan struct{})
+// newHealthServer creates the gRPC health server. Besides the overall status, it reports status of every
+// object storage platform under its ID, which is not serving while the circuit breaker of the platform is not closed.
+func newHealthServer(driverset *provisioner.Driverset, provisionerServer *provisioner.Server) *health.Server {
+	healthServer := health.NewServer()
+
+	driverset.Range(func(d virtualdriver.Driver) bool {
+		healthServer.SetServingStatus(d.ID(), healthpb.HealthCheckResponse_SERVING)
+		return true
+	})
+
+	provisionerServer.OnBreakerStateChange(func(id string, state provisioner.BreakerState) {
+		servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+		if state == provisioner.BreakerClosed {
+			servingStatus = healthpb.HealthCheckResponse_SERVING
+		}
+
+		healthServer.SetServingStatus(id, servingStatus)
+	})
+
+	return healthServer
+}
+
+// start starts the gRPC server. Background workers of the drivers are running until the context is canceled.
+// Once the context is canceled, in-flight requests are given shutdownTimeout to complete, before the server is stopped.
+func (s *Driver) start(ctx context.Context, shutdownTimeout time.Duration) {
+	if s.drivers != nil {
+		s.drivers.Range(func(d virtualdriver.Driver) bool {
+			if w, ok := d.(virtualdriver.Worker); ok {
+				log.Infof("Starting background worker for object storage %s", d.ID())
+				go w.Start(ctx)
+			}
+
+			return true
+		})
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		close(s.ready)
+
+		serveErr <- s.server.Serve(s.lis)
+	}()
+
 	go func() {
-		close(ready)
+		defer close(s.done)
 
-		if err := s.server.Serve(s.lis); err != nil {
+		select {
+		case <-ctx.Done():
+			s.stop(shutdownTimeout)
+			// Serve returns nil once the server is stopped.
+			s.err = <-serveErr
+
+		case err := <-serveErr:
+			// Serve never returns nil before the server is stopped, so it failed.
 			log.Errorf("failed to serve gRPC server: %v", err)
-			os.Exit(1)
+			s.err = fmt.Errorf("failed to serve gRPC server: %w", err)
+			s.stop(0)
 		}
 	}()
+}
+
+// EnableDryRun makes the drivers of all object storage platforms log the mutating calls, instead of sending them.
+// It must be called before the driver is served. Nothing is changed, if any platform does not support dry run.
+func (s *Driver) EnableDryRun() error {
+	var (
+		dryRunners []virtualdriver.DryRunner
+		err        error
+	)
+
+	s.drivers.Range(func(d virtualdriver.Driver) bool {
+		dryRunner, ok := d.(virtualdriver.DryRunner)
+		if !ok {
+			err = fmt.Errorf("dry run is not supported by object storage %s", d.ID())
+			return false
+		}
+
+		dryRunners = append(dryRunners, dryRunner)
+
+		return true
+	})
 
-	return ready
+	if err != nil {
+		return err
+	}
+
+	for _, dryRunner := range dryRunners {
+		dryRunner.EnableDryRun()
+	}
+
+	s.dryRun = true
+
+	return nil
+}
+
+// Ready returns a channel that is closed when the driver is ready to serve requests.
+func (s *Driver) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Wait blocks until the driver is stopped, and returns the error which caused the gRPC server to stop, if any.
+func (s *Driver) Wait() error {
+	<-s.done
+	return s.err
 }
 
 // Run starts the gRPC server for the identity and provisioner servers.
-// This function will not block and instead will provide channel for checking when the driver is ready.
-// Await for context if you want the thread you are running this in to block.
-func Run(ctx context.Context, config *config.ConfigSchemaJson, socket, name string) (<-chan struct{}, error) {
+// This function will not block and instead will return the driver, which provides channel for checking when
+// it is ready, and Wait method for blocking until it is stopped. The driver is stopped when the context is canceled.
+func Run(ctx context.Context, config *config.ConfigSchemaJson, address, name string, options ...grpc.ServerOption) (*Driver, error) {
 	// Create new driver
-	driver, err := New(config, socket, name)
+	driver, err := New(config, address, name, options...)
 	if err != nil {
 		log.Errorf("failed to start gRPC server: %v", err)
 		return nil, err
 	}
 
 	log.Debug("gRPC server started")
-	return driver.start(ctx), nil
+	driver.start(ctx, DefaultShutdownTimeout)
+
+	return driver, nil
 }
 
-// RunBlocking is a blocking version of Run.
-func RunBlocking(ctx context.Context, config *config.ConfigSchemaJson, socket, name string) error {
-	// Create new driver
-	driver, err := New(config, socket, name)
-	if err != nil {
-		log.Errorf("failed to start gRPC server: %v", err)
-		return err
+// stop stops accepting new requests and waits for the in-flight ones to complete.
+// Requests still running after the timeout are canceled. The socket is removed once the server is stopped.
+func (s *Driver) stop(timeout time.Duration) {
+	if s.health != nil {
+		// report not serving while the in-flight requests are drained
+		s.health.Shutdown()
 	}
 
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		log.Info("gRPC server stopped gracefully")
+
+	case <-timer.C:
+		log.Warnf("in-flight requests did not complete within %v, canceling them", timeout)
+		s.server.Stop()
+		<-stopped
+	}
+
+	s.removeSocket()
+}
+
+// Close releases the listener and removes the socket of the driver, which failed to be set up after New,
+// and will not be served. It must not be called once the driver is served, as it is stopped with its context.
+func (s *Driver) Close() error {
+	err := s.lis.Close()
+	s.removeSocket()
+
+	return err
+}
+
+// removeSocket removes the UNIX socket of the driver, if it listens on one.
+func (s *Driver) removeSocket() {
+	if s.socket == "" {
+		return
+	}
+
+	if err := os.Remove(s.socket); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Errorf("failed to remove socket: %v", err)
+	}
+}
+
+// Serve starts the gRPC server, and blocks until the driver is stopped.
+// Once the context is canceled, in-flight requests are given shutdownTimeout to complete, before the server is stopped.
+func (s *Driver) Serve(ctx context.Context, shutdownTimeout time.Duration) error {
 	log.Debug("gRPC server started")
-	// Block until driver is ready
-	<-driver.start(ctx)
+	s.start(ctx, shutdownTimeout)
 
-	// Block until context is done
-	<-ctx.Done()
+	// Block until driver is stopped
+	return s.Wait()
+}
 
-	// Gracefully stop the driver
-	driver.server.GracefulStop()
+// RunBlocking is a blocking version of Run. It returns once the driver is stopped.
+// Once the context is canceled, in-flight requests are given shutdownTimeout to complete, before the server is stopped.
+func RunBlocking(
+	ctx context.Context,
+	config *config.ConfigSchemaJson,
+	address, name string,
+	shutdownTimeout time.Duration,
+	options ...grpc.ServerOption,
+) error {
+	// Create new driver
+	driver, err := New(config, address, name, options...)
+	if err != nil {
+		log.Errorf("failed to start gRPC server: %v", err)
+		return err
+	}
 
-	return nil
+	return driver.Serve(ctx, shutdownTimeout)
 }
@@ -18,11 +18,19 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
 
 	"github.com/dell/cosi/pkg/config"
 	"github.com/dell/cosi/pkg/provisioner"
 	"github.com/dell/cosi/pkg/provisioner/objectscale"
 	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
 )
 
 var (
@@ -128,6 +136,12 @@ func TestDriver(t *testing.T) {
 		"run blocking server":                             testDriverRunBlockingServer,
 		"blocking server configuration with duplicate ID": testDriverBlockingServerConfigurationWithDuplicateID,
 		"fail on Listen error":                            testDriverFailOnListen,
+		"fail on socket removal error":                    testDriverFailOnSocketRemoval,
+		"serve error is returned by Wait":                 testDriverServeError,
+		"start background workers":                        testDriverStartBackgroundWorkers,
+		"shutdown drains in-flight requests":              testDriverShutdownDrainsInFlightRequests,
+		"shutdown cancels requests after timeout":         testDriverShutdownCancelsRequestsAfterTimeout,
+		"close removes socket of driver not served":       testDriverCloseRemovesSocket,
 	} {
 		name, test := name, test
 		t.Run(name, func(t *testing.T) {
@@ -224,7 +238,7 @@ func testDriverRunBlockingServer(t *testing.T) {
 
 	ProvisionerNewVirtualDriverFunc = ProvisionerNewVirtualDriverFuncMock
 
-	err := RunBlocking(ctx, testConfigWithConnections, t.TempDir(), "test")
+	err := RunBlocking(ctx, testConfigWithConnections, t.TempDir(), "test", DefaultShutdownTimeout)
 	assert.NoError(t, err)
 }
 
@@ -232,7 +246,7 @@ func testDriverBlockingServerConfigurationWithDuplicateID(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	err := RunBlocking(ctx, testConfigDuplicateID, t.TempDir(), "test")
+	err := RunBlocking(ctx, testConfigDuplicateID, t.TempDir(), "test", DefaultShutdownTimeout)
 	assert.Error(t, err)
 }
 
@@ -256,10 +270,243 @@ func testDriverFailOnListen(t *testing.T) {
 	ProvisionerNewVirtualDriverFunc = ProvisionerNewVirtualDriverFuncMock
 	NetListenFunc = NetListenFuncMock
 
-	err := RunBlocking(ctx, testConfigWithConnections, t.TempDir(), "test")
+	err := RunBlocking(ctx, testConfigWithConnections, t.TempDir(), "test", DefaultShutdownTimeout)
 	assert.Error(t, err)
 }
 
+func testDriverFailOnSocketRemoval(t *testing.T) {
+	dir := t.TempDir()
+	socketPath := path.Join(dir, "cosi.sock")
+	_, err := os.Create(socketPath)
+	assert.NoError(t, err)
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+		OsRemoveAllFunc = os.RemoveAll
+	}()
+
+	ProvisionerNewVirtualDriverFunc = func(_ config.Configuration) (virtualdriver.Driver, error) {
+		return &objectscale.Server{}, nil
+	}
+
+	OsRemoveAllFunc = func(_ string) error {
+		return errors.New("injected remove error for uT")
+	}
+
+	_, err = New(testConfigWithConnections, socketPath, "test")
+	assert.ErrorContains(t, err, "failed to remove socket")
+}
+
+// failingListener is a listener, which fails to accept connections.
+type failingListener struct {
+	net.Listener
+}
+
+func (l *failingListener) Accept() (net.Conn, error) {
+	return nil, errors.New("injected accept error for uT")
+}
+
+func testDriverServeError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+		NetListenFunc = net.Listen
+	}()
+
+	ProvisionerNewVirtualDriverFunc = func(_ config.Configuration) (virtualdriver.Driver, error) {
+		return &objectscale.Server{}, nil
+	}
+
+	NetListenFunc = func(network, address string) (net.Listener, error) {
+		lis, err := net.Listen(network, address)
+		return &failingListener{Listener: lis}, err
+	}
+
+	// RunBlocking should return instead of blocking until the context is canceled
+	err := RunBlocking(ctx, testConfigWithConnections, path.Join(t.TempDir(), "cosi.sock"), "test", DefaultShutdownTimeout)
+	assert.ErrorContains(t, err, "injected accept error for uT")
+	assert.NoError(t, ctx.Err())
+}
+
+// workerDriver is a fake driver with a background worker.
+type workerDriver struct {
+	fake.Driver
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func (d *workerDriver) Start(ctx context.Context) {
+	close(d.started)
+	<-ctx.Done()
+	close(d.stopped)
+}
+
+func testDriverStartBackgroundWorkers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+	}()
+
+	worker := &workerDriver{
+		Driver:  fake.Driver{FakeID: "worker"},
+		started: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	ProvisionerNewVirtualDriverFunc = func(_ config.Configuration) (virtualdriver.Driver, error) {
+		return worker, nil
+	}
+
+	driver, err := Run(ctx, testConfigWithConnections, path.Join(t.TempDir(), "cosi.sock"), "test")
+	assert.NoError(t, err)
+
+	<-driver.Ready()
+	<-worker.started
+
+	// worker should stop together with the driver
+	cancel()
+	<-worker.stopped
+	assert.NoError(t, driver.Wait())
+}
+
+// blockingDriver is a fake driver, which blocks bucket creation until released or canceled.
+type blockingDriver struct {
+	fake.Driver
+	entered  chan struct{}
+	released chan struct{}
+}
+
+func (d *blockingDriver) DriverCreateBucket(ctx context.Context, req *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	close(d.entered)
+
+	select {
+	case <-d.released:
+		return d.Driver.DriverCreateBucket(ctx, req)
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// startBlockingDriver runs the driver with blockingDriver in the background, and sends a request blocked in it.
+// It returns channels delivering results of RunBlocking and of the request.
+func startBlockingDriver(
+	ctx context.Context,
+	t *testing.T,
+	driver *blockingDriver,
+	socket string,
+	shutdownTimeout time.Duration,
+) (<-chan error, <-chan error) {
+	t.Helper()
+
+	ProvisionerNewVirtualDriverFunc = func(_ config.Configuration) (virtualdriver.Driver, error) {
+		return driver, nil
+	}
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- RunBlocking(ctx, testConfigWithConnections, socket, "test", shutdownTimeout)
+	}()
+
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	requestErr := make(chan error, 1)
+	go func() {
+		_, err := cosi.NewProvisionerClient(conn).DriverCreateBucket(context.Background(), &cosi.DriverCreateBucketRequest{
+			Name:       "bucket",
+			Parameters: map[string]string{"id": driver.FakeID},
+		}, grpc.WaitForReady(true))
+		requestErr <- err
+	}()
+
+	select {
+	case <-driver.entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request did not reach the driver")
+	}
+
+	return runErr, requestErr
+}
+
+func testDriverShutdownDrainsInFlightRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+	}()
+
+	driver := &blockingDriver{
+		Driver:   fake.Driver{FakeID: "blocking"},
+		entered:  make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	socket := path.Join(t.TempDir(), "cosi.sock")
+
+	runErr, requestErr := startBlockingDriver(ctx, t, driver, socket, time.Minute)
+
+	cancel()
+
+	// server should wait for the in-flight request
+	select {
+	case <-runErr:
+		t.Fatal("server stopped before the in-flight request completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(driver.released)
+
+	assert.NoError(t, <-requestErr)
+	assert.NoError(t, <-runErr)
+	assert.NoFileExists(t, socket)
+}
+
+func testDriverShutdownCancelsRequestsAfterTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+	}()
+
+	driver := &blockingDriver{
+		Driver:   fake.Driver{FakeID: "blocking"},
+		entered:  make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	socket := path.Join(t.TempDir(), "cosi.sock")
+
+	runErr, requestErr := startBlockingDriver(ctx, t, driver, socket, 100*time.Millisecond)
+
+	cancel()
+
+	assert.Error(t, <-requestErr)
+	assert.NoError(t, <-runErr)
+	assert.NoFileExists(t, socket)
+}
+
+func testDriverCloseRemovesSocket(t *testing.T) {
+	socket := path.Join(t.TempDir(), "cosi.sock")
+
+	driver, err := New(testConfigWithConnections, socket, "test")
+	require.NoError(t, err)
+	assert.FileExists(t, socket)
+
+	require.NoError(t, driver.Close())
+	assert.NoFileExists(t, socket)
+
+	// the address can be reused right away
+	driver, err = New(testConfigWithConnections, socket, "test")
+	require.NoError(t, err)
+	require.NoError(t, driver.Close())
+}
+
 func runWithParameters(t *testing.T, configuration *config.ConfigSchemaJson, socketDirectoryPath string) error {
 	t.Helper()
 
@@ -268,15 +515,120 @@ func runWithParameters(t *testing.T, configuration *config.ConfigSchemaJson, soc
 
 	testSocketPath := path.Join(socketDirectoryPath, "cosi.sock")
 
-	ready, err := Run(ctx, configuration, testSocketPath, "test")
+	driver, err := Run(ctx, configuration, testSocketPath, "test")
 	if err != nil {
 		return err
 	}
 
 	// Block until server is ready
-	<-ready
+	<-driver.Ready()
 	// Cancel context to stop server gracefully
 	cancel()
 
-	return nil
+	return driver.Wait()
+}
+
+// unavailableDriver is a fake driver, which fails bucket creation as unavailable.
+type unavailableDriver struct {
+	fake.Driver
+}
+
+func (d *unavailableDriver) DriverCreateBucket(_ context.Context, _ *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	return nil, status.Error(codes.Unavailable, "unavailable")
+}
+
+func TestHealth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+	}()
+
+	ProvisionerNewVirtualDriverFunc = func(_ config.Configuration) (virtualdriver.Driver, error) {
+		return &unavailableDriver{Driver: fake.Driver{FakeID: "unavailable"}}, nil
+	}
+
+	socket := path.Join(t.TempDir(), "cosi.sock")
+
+	driver, err := Run(ctx, testConfigWithConnections, socket, "test")
+	require.NoError(t, err)
+	<-driver.Ready()
+
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+
+		return resp.Status
+	}
+
+	// overall status and status of the object storage platform
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("unavailable"))
+
+	// platform is not serving once its circuit breaker opens
+	for range provisioner.DefaultFailureThreshold {
+		_, err := cosi.NewProvisionerClient(conn).DriverCreateBucket(ctx, &cosi.DriverCreateBucketRequest{
+			Name:       "bucket",
+			Parameters: map[string]string{"id": "unavailable"},
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("unavailable"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+
+	cancel()
+	assert.NoError(t, driver.Wait())
+}
+
+// dryRunDriver is a fake driver supporting dry run.
+type dryRunDriver struct {
+	fake.Driver
+	enabled bool
+}
+
+func (d *dryRunDriver) EnableDryRun() {
+	d.enabled = true
+}
+
+func TestEnableDryRun(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"supported": func(t *testing.T) {
+			first := &dryRunDriver{Driver: fake.Driver{FakeID: "first"}}
+			second := &dryRunDriver{Driver: fake.Driver{FakeID: "second"}}
+
+			driverset := &provisioner.Driverset{}
+			require.NoError(t, driverset.Add(first))
+			require.NoError(t, driverset.Add(second))
+
+			driver := &Driver{drivers: driverset}
+			require.NoError(t, driver.EnableDryRun())
+
+			assert.True(t, first.enabled)
+			assert.True(t, second.enabled)
+			assert.True(t, driver.Info().DryRun)
+		},
+		"unsupported": func(t *testing.T) {
+			supported := &dryRunDriver{Driver: fake.Driver{FakeID: "supported"}}
+
+			driverset := &provisioner.Driverset{}
+			require.NoError(t, driverset.Add(supported))
+			require.NoError(t, driverset.Add(&fake.Driver{FakeID: "unsupported"}))
+
+			driver := &Driver{drivers: driverset}
+			assert.ErrorContains(t, driver.EnableDryRun(), "dry run is not supported by object storage unsupported")
+
+			// nothing is changed
+			assert.False(t, supported.enabled)
+			assert.False(t, driver.Info().DryRun)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
 }
@@ -0,0 +1,76 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	// httpReadHeaderTimeout limits reading of the request headers by the HTTP servers of the driver.
+	httpReadHeaderTimeout = 10 * time.Second
+	// httpShutdownTimeout is the time given to the in-flight HTTP requests to complete on shutdown.
+	httpShutdownTimeout = 5 * time.Second
+)
+
+// Listen announces on the address, which is either a UNIX socket path, unix://<path> or tcp://<host>:<port>.
+// Stale socket left by the previous run is removed. The socket is removed when the listener is closed.
+func Listen(address string) (net.Listener, error) {
+	network, address, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unix" {
+		if _, err := os.Stat(address); !errors.Is(err, fs.ErrNotExist) {
+			if err := OsRemoveAllFunc(address); err != nil {
+				return nil, fmt.Errorf("failed to remove socket: %w", err)
+			}
+		}
+	}
+
+	listener, err := NetListenFunc(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to announce on the local network address: %w", err)
+	}
+
+	return listener, nil
+}
+
+// serveHTTP serves the handler on the listener in the background, until the context is canceled.
+func serveHTTP(ctx context.Context, listener net.Listener, handler http.Handler) {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("failed to serve HTTP server on %s: %v", listener.Addr(), err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("failed to shut down HTTP server on %s: %v", listener.Addr(), err)
+		}
+	}()
+}
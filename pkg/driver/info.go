@@ -0,0 +1,91 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/version"
+)
+
+// InfoPath is the HTTP path on which the info of the driver is served.
+const InfoPath = "/info"
+
+// BackendInfo describes the object storage platform configured for the driver.
+type BackendInfo struct {
+	ID        string   `json:"id"`
+	Platform  string   `json:"platform,omitempty"`
+	Protocols []string `json:"protocols,omitempty"`
+	Features  []string `json:"features,omitempty"`
+}
+
+// Info describes the running driver and its object storage platforms.
+type Info struct {
+	Name     string        `json:"name"`
+	Version  string        `json:"version"`
+	Commit   string        `json:"commit"`
+	Backends []BackendInfo `json:"backends"`
+	// DryRun is set, when the mutating calls to the object storage platforms are not sent.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Info returns the info of the driver. Backends are sorted by their IDs.
+func (s *Driver) Info() Info {
+	info := Info{
+		Name:     s.name,
+		Version:  version.Version,
+		Commit:   version.GitCommit(),
+		Backends: []BackendInfo{},
+		DryRun:   s.dryRun,
+	}
+
+	if s.drivers != nil {
+		s.drivers.Range(func(d virtualdriver.Driver) bool {
+			backend := BackendInfo{ID: d.ID()}
+
+			if describer, ok := d.(virtualdriver.Describer); ok {
+				capabilities := describer.Capabilities()
+				backend.Platform = capabilities.Platform
+				backend.Protocols = capabilities.Protocols
+				backend.Features = capabilities.Features
+			}
+
+			info.Backends = append(info.Backends, backend)
+
+			return true
+		})
+	}
+
+	slices.SortFunc(info.Backends, func(a, b BackendInfo) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return info
+}
+
+// infoHandler serves the info of the driver as JSON.
+func (s *Driver) infoHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+InfoPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, s.Info())
+	})
+
+	return mux
+}
+
+// ServeInfo serves the info of the driver over HTTP on the listener, until the context is canceled.
+func (s *Driver) ServeInfo(ctx context.Context, listener net.Listener) {
+	log.Infof("Serving driver info on %s%s", listener.Addr(), InfoPath)
+	serveHTTP(ctx, listener, s.infoHandler())
+}
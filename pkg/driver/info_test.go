@@ -0,0 +1,126 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/cosi/pkg/provisioner"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+	"github.com/dell/cosi/pkg/version"
+)
+
+// describedDriver is a fake driver reporting its capabilities.
+type describedDriver struct {
+	fake.Driver
+}
+
+func (d *describedDriver) Capabilities() virtualdriver.Capabilities {
+	return virtualdriver.Capabilities{Platform: "fake", Protocols: []string{"s3"}, Features: []string{"limits"}}
+}
+
+func TestInfo(t *testing.T) {
+	driverset := &provisioner.Driverset{}
+	require.NoError(t, driverset.Add(&describedDriver{fake.Driver{FakeID: "described"}}))
+	require.NoError(t, driverset.Add(&fake.Driver{FakeID: "basic"}))
+
+	driver := &Driver{name: "cosi.dellemc.com", drivers: driverset}
+
+	server := httptest.NewServer(driver.infoHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + InfoPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var info Info
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+
+	assert.Equal(t, Info{
+		Name:    "cosi.dellemc.com",
+		Version: version.Version,
+		Commit:  version.GitCommit(),
+		Backends: []BackendInfo{
+			{ID: "basic"},
+			{ID: "described", Platform: "fake", Protocols: []string{"s3"}, Features: []string{"limits"}},
+		},
+	}, info)
+
+	// only reads are allowed
+	resp, err = http.Post(server.URL+InfoPath, "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServeInfo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	listener, err := Listen("tcp://127.0.0.1:0")
+	require.NoError(t, err)
+
+	driver := &Driver{name: "test"}
+	driver.ServeInfo(ctx, listener)
+
+	resp, err := http.Get("http://" + listener.Addr().String() + InfoPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// server is stopped with the context
+	cancel()
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + listener.Addr().String() + InfoPath)
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestListen(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"StaleSocketIsReplaced": func(t *testing.T) {
+			socket := path.Join(t.TempDir(), "info.sock")
+
+			stale, err := Listen(socket)
+			require.NoError(t, err)
+			defer stale.Close()
+
+			listener, err := Listen("unix://" + socket)
+			require.NoError(t, err)
+			assert.NoError(t, listener.Close())
+		},
+		"InvalidAddress": func(t *testing.T) {
+			_, err := Listen("http://localhost:8080")
+			assert.Error(t, err)
+		},
+		"ListenFailure": func(t *testing.T) {
+			_, err := Listen("tcp://256.0.0.1:0")
+			assert.Error(t, err)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	spec "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/logger"
+	"github.com/dell/csmlog"
+)
+
+const (
+	// RequestIDHeader is the gRPC metadata key carrying the correlation ID of the request.
+	// It is taken from the incoming request if present, generated otherwise, and returned in the response header.
+	RequestIDHeader = "x-request-id"
+
+	// redacted replaces the credential secrets in the logged responses.
+	redacted = "[REDACTED]"
+)
+
+// defaultServerOptions returns gRPC server options with the OpenTelemetry instrumentation and the interceptor chain.
+func defaultServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Request ID is assigned first, so it is available to the following interceptors,
+		// while the recovery is the innermost, so the converted panics are logged as any other error.
+		grpc.ChainUnaryInterceptor(requestIDInterceptor, loggingInterceptor, recoveryInterceptor),
+	}
+}
+
+// requestIDInterceptor adds the correlation ID of the request to the context, the response header and the span.
+func requestIDInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID)); err != nil {
+		log.Debugf("failed to set request ID header: %v", err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("cosi.request_id", requestID))
+
+	return handler(logger.ContextWithRequestID(ctx, requestID), req)
+}
+
+// loggingInterceptor logs every request with its result and duration. Payloads are logged on debug level,
+// with the credential secrets redacted.
+func loggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	log.WithFields(csmlog.Fields{
+		"method":              info.FullMethod,
+		logger.RequestIDField: logger.RequestID(ctx),
+		"request":             req,
+	}).Debug("Request received")
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	fields := csmlog.Fields{
+		"method":              info.FullMethod,
+		logger.RequestIDField: logger.RequestID(ctx),
+		"code":                status.Code(err).String(),
+		"duration":            time.Since(start).String(),
+	}
+
+	if err != nil {
+		fields["error"] = err
+		log.WithFields(fields).Error("Request failed")
+
+		return resp, err
+	}
+
+	log.WithFields(fields).Info("Request completed")
+	log.WithFields(csmlog.Fields{
+		"method":              info.FullMethod,
+		logger.RequestIDField: logger.RequestID(ctx),
+		"response":            redact(resp),
+	}).Debug("Response sent")
+
+	return resp, nil
+}
+
+// recoveryInterceptor converts panics in the handlers into Internal errors, so a bug in a single driver
+// does not crash the whole process.
+func recoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.WithFields(csmlog.Fields{
+				"method":              info.FullMethod,
+				logger.RequestIDField: logger.RequestID(ctx),
+				"panic":               r,
+				"stack":               string(debug.Stack()),
+			}).Error("Recovered from panic")
+
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// redact returns a copy of the response with the credential secrets replaced, so it can be logged.
+func redact(resp any) any {
+	grant, ok := resp.(*spec.DriverGrantBucketAccessResponse)
+	if !ok || grant == nil {
+		return resp
+	}
+
+	grant = proto.Clone(grant).(*spec.DriverGrantBucketAccessResponse)
+	for _, credentials := range grant.Credentials {
+		if credentials == nil {
+			continue
+		}
+
+		for key := range credentials.Secrets {
+			credentials.Secrets[key] = redacted
+		}
+	}
+
+	return grant
+}
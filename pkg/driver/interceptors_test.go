@@ -0,0 +1,161 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/logger"
+	"github.com/dell/cosi/pkg/provisioner"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+)
+
+var testServerInfo = &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Generated": func(t *testing.T) {
+			var requestID string
+
+			_, err := requestIDInterceptor(context.Background(), nil, testServerInfo, func(ctx context.Context, _ any) (any, error) {
+				requestID = logger.RequestID(ctx)
+				return nil, nil
+			})
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, requestID)
+		},
+		"FromMetadata": func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "from-sidecar"))
+
+			var requestID string
+
+			_, err := requestIDInterceptor(ctx, nil, testServerInfo, func(ctx context.Context, _ any) (any, error) {
+				requestID = logger.RequestID(ctx)
+				return nil, nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "from-sidecar", requestID)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	resp, err := loggingInterceptor(context.Background(), "request", testServerInfo, func(_ context.Context, _ any) (any, error) {
+		return "response", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+
+	_, err = loggingInterceptor(context.Background(), "request", testServerInfo, func(_ context.Context, _ any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	resp, err := recoveryInterceptor(context.Background(), nil, testServerInfo, func(_ context.Context, _ any) (any, error) {
+		panic("driver bug")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// errors of the handler are returned unchanged
+	handlerErr := errors.New("failed")
+	_, err = recoveryInterceptor(context.Background(), nil, testServerInfo, func(_ context.Context, _ any) (any, error) {
+		return nil, handlerErr
+	})
+	assert.Equal(t, handlerErr, err)
+}
+
+func TestRedact(t *testing.T) {
+	resp := &cosi.DriverGrantBucketAccessResponse{
+		AccountId: "account",
+		Credentials: map[string]*cosi.CredentialDetails{
+			"s3": {Secrets: map[string]string{"accessKeyID": "key", "accessSecretKey": "secret"}},
+		},
+	}
+
+	redactedResp, ok := redact(resp).(*cosi.DriverGrantBucketAccessResponse)
+	require.True(t, ok)
+
+	assert.Equal(t, "account", redactedResp.AccountId)
+	assert.Equal(t, map[string]string{"accessKeyID": redacted, "accessSecretKey": redacted}, redactedResp.Credentials["s3"].Secrets)
+	// the original response is returned to the client unchanged
+	assert.Equal(t, "secret", resp.Credentials["s3"].Secrets["accessSecretKey"])
+
+	other := &cosi.DriverCreateBucketResponse{BucketId: "bucket"}
+	assert.Equal(t, other, redact(other))
+}
+
+// panickingDriver is a fake driver, which panics on bucket creation.
+type panickingDriver struct {
+	fake.Driver
+}
+
+func (d *panickingDriver) DriverCreateBucket(_ context.Context, _ *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	panic("driver bug")
+}
+
+func TestServerRecoversFromPanic(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	defer func() {
+		ProvisionerNewVirtualDriverFunc = provisioner.NewVirtualDriver
+	}()
+
+	ProvisionerNewVirtualDriverFunc = func(_ config.Configuration) (virtualdriver.Driver, error) {
+		return &panickingDriver{Driver: fake.Driver{FakeID: "panicking"}}, nil
+	}
+
+	socket := path.Join(t.TempDir(), "cosi.sock")
+
+	driver, err := Run(ctx, testConfigWithConnections, socket, "test")
+	require.NoError(t, err)
+	<-driver.Ready()
+
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var header metadata.MD
+
+	_, err = cosi.NewProvisionerClient(conn).DriverCreateBucket(ctx, &cosi.DriverCreateBucketRequest{
+		Name:       "bucket",
+		Parameters: map[string]string{"id": "panicking"},
+	}, grpc.Header(&header))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.NotEmpty(t, header.Get(RequestIDHeader))
+
+	// server keeps serving after the panic
+	_, err = cosi.NewIdentityClient(conn).DriverGetInfo(ctx, &cosi.DriverGetInfoRequest{})
+	assert.NoError(t, err)
+
+	cancel()
+	assert.NoError(t, driver.Wait())
+}
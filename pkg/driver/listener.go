@@ -0,0 +1,83 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	unixScheme = "unix://"
+	tcpScheme  = "tcp://"
+)
+
+// ParseAddress returns network and address of the listener, from either a UNIX socket path,
+// unix://<path> or tcp://<host>:<port>.
+func ParseAddress(address string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(address, tcpScheme):
+		hostPort := strings.TrimPrefix(address, tcpScheme)
+		if hostPort == "" {
+			return "", "", fmt.Errorf("invalid address %s: empty host and port", address)
+		}
+
+		return "tcp", hostPort, nil
+
+	case strings.HasPrefix(address, unixScheme):
+		address = strings.TrimPrefix(address, unixScheme)
+
+	case strings.Contains(address, "://"):
+		return "", "", fmt.Errorf("invalid address %s: expected unix:// or tcp:// scheme", address)
+	}
+
+	if address == "" {
+		return "", "", errors.New("invalid address: empty socket path")
+	}
+
+	return "unix", address, nil
+}
+
+// ServerTLSOption creates the gRPC server option enabling TLS with the certificate and key files.
+// If the client CA file is provided, clients are required to present a certificate signed by it (mTLS).
+func ServerTLSOption(certFile, keyFile, clientCAFile string) (grpc.ServerOption, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if clientCAFile != "" {
+		ca, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse client CA %s", clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
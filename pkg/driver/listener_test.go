@@ -0,0 +1,224 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package driver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		name            string
+		address         string
+		expectedNetwork string
+		expectedAddress string
+		wantErr         bool
+	}{
+		{
+			name:            "socket path",
+			address:         COSISocket,
+			expectedNetwork: "unix",
+			expectedAddress: COSISocket,
+		},
+		{
+			name:            "unix scheme",
+			address:         "unix:///tmp/cosi.sock",
+			expectedNetwork: "unix",
+			expectedAddress: "/tmp/cosi.sock",
+		},
+		{
+			name:            "tcp scheme",
+			address:         "tcp://127.0.0.1:9000",
+			expectedNetwork: "tcp",
+			expectedAddress: "127.0.0.1:9000",
+		},
+		{
+			name:    "empty tcp address",
+			address: "tcp://",
+			wantErr: true,
+		},
+		{
+			name:    "empty socket path",
+			address: "unix://",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			address: "http://127.0.0.1:9000",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, address, err := ParseAddress(tt.address)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedNetwork, network)
+			assert.Equal(t, tt.expectedAddress, address)
+		})
+	}
+}
+
+// testCertificate is a PEM encoded certificate and key, signed by the parent if any.
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	file := path.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	return file
+}
+
+func TestServerTLSOption(t *testing.T) {
+	ca := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+	server := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	client := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	certFile := writeTestFile(t, "tls.crt", server.certPEM)
+	keyFile := writeTestFile(t, "tls.key", server.keyPEM)
+	caFile := writeTestFile(t, "ca.crt", ca.certPEM)
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"MutualTLS": func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			option, err := ServerTLSOption(certFile, keyFile, caFile)
+			require.NoError(t, err)
+
+			driver, err := Run(ctx, testConfigEmpty, "tcp://127.0.0.1:0", "test", option)
+			require.NoError(t, err)
+			<-driver.Ready()
+
+			roots := x509.NewCertPool()
+			roots.AddCert(ca.cert)
+
+			call := func(certificates []tls.Certificate) error {
+				conn, err := grpc.NewClient(driver.lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					RootCAs:      roots,
+					Certificates: certificates,
+				})))
+				require.NoError(t, err)
+				defer conn.Close()
+
+				_, err = cosi.NewIdentityClient(conn).DriverGetInfo(ctx, &cosi.DriverGetInfoRequest{})
+
+				return err
+			}
+
+			clientCert, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+			require.NoError(t, err)
+
+			// client with certificate signed by the CA is accepted
+			assert.NoError(t, call([]tls.Certificate{clientCert}))
+			// client without certificate is refused
+			assert.Error(t, call(nil))
+
+			cancel()
+			assert.NoError(t, driver.Wait())
+		},
+		"InvalidKeyPair": func(t *testing.T) {
+			_, err := ServerTLSOption(certFile, caFile, "")
+			assert.Error(t, err)
+		},
+		"MissingClientCA": func(t *testing.T) {
+			_, err := ServerTLSOption(certFile, keyFile, path.Join(t.TempDir(), "missing.crt"))
+			assert.Error(t, err)
+		},
+		"InvalidClientCA": func(t *testing.T) {
+			_, err := ServerTLSOption(certFile, keyFile, keyFile)
+			assert.Error(t, err)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
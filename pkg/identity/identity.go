@@ -11,6 +11,8 @@ package identity
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
 	"github.com/dell/csmlog"
 	"google.golang.org/grpc/codes"
@@ -31,6 +33,20 @@ var (
 )
 
 // New returns new server.
+// namePattern is the format of the driver name required by COSI specification: up to 63 characters,
+// beginning and ending with an alphanumeric character, with dashes, dots and alphanumerics between.
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9]([-.a-zA-Z0-9]{0,61}[a-zA-Z0-9])?$`)
+
+// ValidateName checks if the driver name follows the format required by COSI specification.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid driver name %q, expected up to 63 alphanumeric characters, '.' or '-', "+
+			"beginning and ending with an alphanumeric character", name)
+	}
+
+	return nil
+}
+
 func New(provisioner string) *Server {
 	return &Server{
 		name: provisioner,
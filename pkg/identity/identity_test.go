@@ -10,6 +10,7 @@ package identity
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/dell/cosi/pkg/internal/testcontext"
@@ -68,3 +69,32 @@ func testMissingProvisionerName(t *testing.T) {
 		t.Errorf("expected error, got nil")
 	}
 }
+
+func TestValidateName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "cosi.dellemc.com"},
+		{name: "cosi-2.dellemc.com"},
+		{name: "a"},
+		{name: strings.Repeat("a", 63)},
+		{name: "", wantErr: true},
+		{name: strings.Repeat("a", 64), wantErr: true},
+		{name: "-cosi.dellemc.com", wantErr: true},
+		{name: "cosi.dellemc.com.", wantErr: true},
+		{name: "cosi_dellemc", wantErr: true},
+		{name: "cosi/dellemc", wantErr: true},
+	} {
+		err := ValidateName(tc.name)
+		if tc.wantErr && err == nil {
+			t.Errorf("%q: expected error, got nil", tc.name)
+		}
+
+		if !tc.wantErr && err != nil {
+			t.Errorf("%q: got unexpected error: %s", tc.name, err.Error())
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package logger
+
+import "context"
+
+// RequestIDField is the log field holding the correlation ID of the gRPC request.
+const RequestIDField = "requestID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of the context carrying the correlation ID of the request.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the correlation ID of the request, or empty string if the context does not carry one.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,318 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package provisioner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+const (
+	// DefaultFailureThreshold is the number of consecutive failures opening the circuit breaker.
+	DefaultFailureThreshold = 5
+	// DefaultOpenTimeout is the time the circuit breaker stays open before it is half-opened.
+	DefaultOpenTimeout = 30 * time.Second
+	// DefaultProbeTimeout limits the probe of the backend, made when the circuit breaker is half-opened.
+	DefaultProbeTimeout = 5 * time.Second
+
+	// ErrBackendUnavailable is returned while the circuit breaker of the backend is open.
+	ErrBackendUnavailable = "object storage platform is unavailable"
+
+	breakerMeterName = "CircuitBreaker"
+)
+
+// BreakerState is the state of the circuit breaker of a backend.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all requests through to the backend.
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets through the probe, or a single trial request, if the driver cannot be probed.
+	BreakerHalfOpen
+	// BreakerOpen fails all requests fast.
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerListener is notified about the state changes of the circuit breakers.
+type BreakerListener func(id string, state BreakerState)
+
+// breakerStateGauge reports the state of the circuit breakers, as numeric value of BreakerState.
+var breakerStateGauge, _ = otel.Meter(breakerMeterName).Int64Gauge("cosi_circuit_breaker_state",
+	metric.WithDescription("State of the circuit breaker of the object storage platform: 0 - closed, 1 - half-open, 2 - open"))
+
+// isBackendFailure checks if the error indicates that the backend is unavailable.
+// Other errors, e.g. invalid requests, do not count as failures.
+func isBackendFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// circuitBreaker fails the requests to the backend fast, after it failed consecutively.
+type circuitBreaker struct {
+	id               string
+	prober           driver.Prober
+	failureThreshold int
+	openTimeout      time.Duration
+	probeTimeout     time.Duration
+	listener         BreakerListener
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	// trial is true while the trial request is in progress in the half-open state.
+	trial bool
+}
+
+// allow checks if the request can be sent to the backend.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return true
+
+	case BreakerOpen:
+		// Drivers which can be probed are half-opened by the probe.
+		if b.prober != nil || time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+
+		b.setState(BreakerHalfOpen)
+		b.trial = true
+
+		return true
+
+	default:
+		if b.prober != nil || b.trial {
+			return false
+		}
+
+		b.trial = true
+
+		return true
+	}
+}
+
+// record records the result of the request allowed by the circuit breaker.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.state == BreakerOpen:
+		// result of the request started before the breaker opened
+		return
+
+	case isBackendFailure(err):
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+			b.open()
+		}
+
+	default:
+		b.failures = 0
+		if b.state == BreakerHalfOpen {
+			b.trial = false
+			b.setState(BreakerClosed)
+		}
+	}
+}
+
+// discard drops the result of the request allowed by the circuit breaker, which does not tell if the backend
+// is available. The trial request of the half-open breaker is allowed again.
+func (b *circuitBreaker) discard() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.trial = false
+	}
+}
+
+// open opens the circuit breaker, and schedules the probe, if the driver can be probed. It must be called with the lock held.
+func (b *circuitBreaker) open() {
+	b.openedAt = time.Now()
+	b.trial = false
+	b.setState(BreakerOpen)
+
+	if b.prober != nil {
+		time.AfterFunc(b.openTimeout, b.probe)
+	}
+}
+
+// probe half-opens the circuit breaker and probes the backend. The breaker is closed if the probe succeeds,
+// and opened again otherwise.
+func (b *circuitBreaker) probe() {
+	b.mu.Lock()
+	if b.state != BreakerOpen {
+		b.mu.Unlock()
+		return
+	}
+
+	b.setState(BreakerHalfOpen)
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.probeTimeout)
+	defer cancel()
+
+	err := b.prober.Probe(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != BreakerHalfOpen {
+		return
+	}
+
+	if err != nil {
+		log.WithFields(csmlog.Fields{"id": b.id, "error": err}).Warn("Probe of object storage platform failed")
+		b.open()
+
+		return
+	}
+
+	b.failures = 0
+	b.setState(BreakerClosed)
+}
+
+// setState changes the state, logs the change and notifies the listener. It must be called with the lock held.
+func (b *circuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	b.state = state
+
+	fields := csmlog.Fields{"id": b.id, "state": state.String()}
+	if state == BreakerOpen {
+		log.WithFields(fields).Warnf("Circuit breaker opened after %d consecutive failures", b.failures)
+	} else {
+		log.WithFields(fields).Info("Circuit breaker state changed")
+	}
+
+	breakerStateGauge.Record(context.Background(), int64(state), metric.WithAttributes(attribute.String("id", b.id)))
+
+	if b.listener != nil {
+		b.listener(b.id, state)
+	}
+}
+
+// breakers holds the circuit breakers of the backends, created on the first request.
+type breakers struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	probeTimeout     time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+	listener BreakerListener
+}
+
+func newBreakers() *breakers {
+	return &breakers{
+		failureThreshold: DefaultFailureThreshold,
+		openTimeout:      DefaultOpenTimeout,
+		probeTimeout:     DefaultProbeTimeout,
+		breakers:         map[string]*circuitBreaker{},
+	}
+}
+
+// setListener sets the listener of the circuit breakers created afterwards.
+func (bs *breakers) setListener(listener BreakerListener) {
+	if bs == nil {
+		return
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	bs.listener = listener
+}
+
+// get returns the circuit breaker of the driver. Nil breakers return nil circuit breaker, which allows all requests.
+func (bs *breakers) get(d driver.Driver) *circuitBreaker {
+	if bs == nil {
+		return nil
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	b, ok := bs.breakers[d.ID()]
+	if !ok {
+		prober, _ := d.(driver.Prober)
+		b = &circuitBreaker{
+			id:               d.ID(),
+			prober:           prober,
+			failureThreshold: bs.failureThreshold,
+			openTimeout:      bs.openTimeout,
+			probeTimeout:     bs.probeTimeout,
+			listener:         bs.listener,
+		}
+		bs.breakers[d.ID()] = b
+	}
+
+	return b
+}
+
+// call sends the request to the backend, unless the circuit breaker is open.
+// The request must be admitted by the limiter of the backend first, see coalesce,
+// so the requests rejected by the limiter are not counted as failures of the backend.
+func call[Resp any](ctx context.Context, b *circuitBreaker, send func() (Resp, error)) (Resp, error) {
+	var empty Resp
+
+	if b == nil {
+		return send()
+	}
+
+	if !b.allow() {
+		return empty, status.Error(codes.Unavailable, ErrBackendUnavailable)
+	}
+
+	resp, err := send()
+
+	// Failures after the deadline or cancellation of the caller may be caused by the caller itself,
+	// e.g. by a deadline too short for the request, so they say nothing about the backend.
+	if err != nil && ctx.Err() != nil {
+		b.discard()
+	} else {
+		b.record(err)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,210 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package provisioner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "unavailable")
+
+// unavailableDriver is a fake driver, which fails bucket creation while the backend is down.
+type unavailableDriver struct {
+	fake.Driver
+	down  atomic.Bool
+	calls atomic.Int32
+}
+
+func (d *unavailableDriver) DriverCreateBucket(ctx context.Context, req *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	d.calls.Add(1)
+
+	if d.down.Load() {
+		return nil, errUnavailable
+	}
+
+	return d.Driver.DriverCreateBucket(ctx, req)
+}
+
+// probedDriver is an unavailable driver, which can be probed.
+type probedDriver struct {
+	unavailableDriver
+}
+
+func (d *probedDriver) Probe(_ context.Context) error {
+	if d.down.Load() {
+		return errors.New("down")
+	}
+
+	return nil
+}
+
+func testBreakers(listener BreakerListener) *breakers {
+	bs := newBreakers()
+	bs.failureThreshold = 2
+	bs.openTimeout = 10 * time.Millisecond
+	bs.listener = listener
+
+	return bs
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"OpensAfterConsecutiveFailures": func(t *testing.T) {
+			b := testBreakers(nil).get(&fake.Driver{FakeID: "fake"})
+
+			for range 2 {
+				require.True(t, b.allow())
+				b.record(errUnavailable)
+			}
+
+			assert.Equal(t, BreakerOpen, b.state)
+			assert.False(t, b.allow())
+		},
+		"OtherErrorsAreNotFailures": func(t *testing.T) {
+			b := testBreakers(nil).get(&fake.Driver{FakeID: "fake"})
+
+			b.record(errUnavailable)
+			b.record(status.Error(codes.InvalidArgument, "invalid"))
+			b.record(errUnavailable)
+			b.record(nil)
+			b.record(errUnavailable)
+
+			assert.Equal(t, BreakerClosed, b.state)
+		},
+		"TrialRequestClosesBreaker": func(t *testing.T) {
+			b := testBreakers(nil).get(&fake.Driver{FakeID: "fake"})
+			b.record(errUnavailable)
+			b.record(errUnavailable)
+
+			time.Sleep(20 * time.Millisecond)
+
+			// single trial request is allowed
+			require.True(t, b.allow())
+			assert.Equal(t, BreakerHalfOpen, b.state)
+			assert.False(t, b.allow())
+
+			b.record(nil)
+			assert.Equal(t, BreakerClosed, b.state)
+			assert.True(t, b.allow())
+		},
+		"FailedTrialRequestOpensBreaker": func(t *testing.T) {
+			b := testBreakers(nil).get(&fake.Driver{FakeID: "fake"})
+			b.record(errUnavailable)
+			b.record(errUnavailable)
+
+			time.Sleep(20 * time.Millisecond)
+
+			require.True(t, b.allow())
+			b.record(errUnavailable)
+
+			assert.Equal(t, BreakerOpen, b.state)
+			assert.False(t, b.allow())
+		},
+		"ProbeClosesBreaker": func(t *testing.T) {
+			states := make(chan BreakerState, 10)
+			d := &probedDriver{unavailableDriver{Driver: fake.Driver{FakeID: "probed"}}}
+			d.down.Store(true)
+
+			b := testBreakers(func(_ string, state BreakerState) { states <- state }).get(d)
+			b.record(errUnavailable)
+			b.record(errUnavailable)
+			assert.Equal(t, BreakerOpen, <-states)
+
+			// requests fail fast, until the probe succeeds
+			assert.False(t, b.allow())
+
+			// failed probe opens the breaker again
+			assert.Equal(t, BreakerHalfOpen, <-states)
+			assert.Equal(t, BreakerOpen, <-states)
+
+			d.down.Store(false)
+
+			assert.Equal(t, BreakerHalfOpen, <-states)
+			assert.Equal(t, BreakerClosed, <-states)
+			assert.True(t, b.allow())
+		},
+		"CallerDeadlineIsNotFailure": func(t *testing.T) {
+			b := testBreakers(nil).get(&fake.Driver{FakeID: "fake"})
+
+			for range 3 {
+				ctx, cancel := context.WithCancel(context.Background())
+				_, err := call(ctx, b, func() (struct{}, error) {
+					cancel()
+					return struct{}{}, status.Error(codes.DeadlineExceeded, "deadline exceeded")
+				})
+				assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+			}
+
+			assert.Equal(t, BreakerClosed, b.state)
+			assert.Zero(t, b.failures)
+		},
+		"DiscardedTrialRequestAllowsNextTrial": func(t *testing.T) {
+			b := testBreakers(nil).get(&fake.Driver{FakeID: "fake"})
+			b.record(errUnavailable)
+			b.record(errUnavailable)
+
+			time.Sleep(20 * time.Millisecond)
+
+			require.True(t, b.allow())
+			b.discard()
+
+			assert.Equal(t, BreakerHalfOpen, b.state)
+			assert.True(t, b.allow())
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestServerCircuitBreaker(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	d := &unavailableDriver{Driver: fake.Driver{FakeID: "unavailable"}}
+	d.down.Store(true)
+
+	driverset := &Driverset{}
+	require.NoError(t, driverset.Add(d))
+
+	var opened atomic.Bool
+
+	server := New(driverset)
+	server.OnBreakerStateChange(func(id string, state BreakerState) {
+		assert.Equal(t, "unavailable", id)
+		opened.Store(state == BreakerOpen)
+	})
+
+	req := &cosi.DriverCreateBucketRequest{Name: "bucket", Parameters: map[string]string{"id": "unavailable"}}
+
+	for range DefaultFailureThreshold {
+		_, err := server.DriverCreateBucket(ctx, req)
+		assert.ErrorIs(t, err, errUnavailable)
+	}
+
+	assert.True(t, opened.Load())
+
+	// request fails fast, without reaching the driver
+	_, err := server.DriverCreateBucket(ctx, req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, ErrBackendUnavailable, status.Convert(err).Message())
+	assert.Equal(t, int32(DefaultFailureThreshold), d.calls.Load())
+}
@@ -0,0 +1,324 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package provisioner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dell/csmlog"
+)
+
+// flightKey identifies the request by the backend, the operation and the hash of all its fields, including
+// the parameters. Requests with the same key are treated as identical, e.g. when the COSI sidecar resends
+// the request while the first one is still in progress.
+func flightKey(id, operation string, req proto.Message) string {
+	// Deterministic marshaling orders the parameters, so equal requests give equal keys.
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		// the request is never shared with another one
+		return fmt.Sprintf("%s/%s/%p", id, operation, req)
+	}
+
+	hash := sha256.Sum256(raw)
+
+	return fmt.Sprintf("%s/%s/%s", id, operation, hex.EncodeToString(hash[:]))
+}
+
+// flightPanic is the panic recovered in the shared execution, with the stack where it happened.
+type flightPanic struct {
+	value any
+	stack []byte
+}
+
+func (p *flightPanic) String() string {
+	return fmt.Sprintf("%v\n\n%s", p.value, p.stack)
+}
+
+// flightGroup shares a single execution between identical requests in progress. Zero value is ready to use.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is the execution shared by the callers waiting for its result.
+type flight struct {
+	ctx *flightContext
+	// admitted is closed, once the execution is admitted, and done, once its result is set.
+	admitted chan struct{}
+	done     chan struct{}
+	val      any
+	err      error
+	// rejected is set, if the admission failed, with the deadline which bounded the admission.
+	rejected         bool
+	rejectedDeadline time.Time
+	shared           bool
+}
+
+// join adds the caller to the execution in progress with the key, or starts a new one.
+func (g *flightGroup) join(
+	ctx context.Context,
+	key string,
+	admit func(ctx context.Context) (release func(), err error),
+	exec func(ctx context.Context) (any, error),
+) (*flight, *waiter) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	w := newWaiter(ctx)
+
+	if f, ok := g.flights[key]; ok {
+		f.shared = true
+		f.ctx.add(w)
+
+		return f, w
+	}
+
+	f := &flight{
+		ctx:      newFlightContext(ctx),
+		admitted: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	f.ctx.add(w)
+
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+
+	g.flights[key] = f
+
+	go g.run(key, f, admit, exec)
+
+	return f, w
+}
+
+// leave removes the caller, whose context is done, from the execution. The execution is canceled with the error
+// of the context, when its last caller leaves, as there is no one waiting for its result. It returns true then.
+func (g *flightGroup) leave(key string, f *flight, w *waiter, err error) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !f.ctx.remove(w) {
+		return false
+	}
+
+	// the callers arriving later start a new execution
+	g.forget(key, f)
+	f.ctx.cancel(err)
+
+	return true
+}
+
+// forget removes the execution, so it is no longer joined. It must be called with the lock held.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
+
+// run admits and executes the request, and passes the result to the callers.
+func (g *flightGroup) run(
+	key string,
+	f *flight,
+	admit func(ctx context.Context) (release func(), err error),
+	exec func(ctx context.Context) (any, error),
+) {
+	defer func() {
+		// The execution runs in its own goroutine, where the panic would crash the process,
+		// so it is passed to the callers and panics again in each of them.
+		if r := recover(); r != nil {
+			f.val, f.err = &flightPanic{value: r, stack: debug.Stack()}, nil
+		}
+
+		g.mu.Lock()
+		g.forget(key, f)
+		g.mu.Unlock()
+
+		f.ctx.cancel(context.Canceled)
+		close(f.done)
+	}()
+
+	deadline, _ := f.ctx.Deadline()
+
+	release, err := admit(f.ctx)
+	if err != nil {
+		f.err, f.rejected, f.rejectedDeadline = err, true, deadline
+		return
+	}
+	defer release()
+
+	close(f.admitted)
+
+	f.val, f.err = exec(f.ctx)
+}
+
+// waiter is the caller waiting for the execution, with its deadline, or zero if it has none.
+type waiter struct {
+	deadline time.Time
+}
+
+func newWaiter(ctx context.Context) *waiter {
+	deadline, _ := ctx.Deadline()
+
+	return &waiter{deadline: deadline}
+}
+
+// flightContext is the context of the execution shared by the callers. It has the values of the caller,
+// which started the execution, and the latest deadline of the callers waiting for the result,
+// so the execution is limited by their deadlines, as if it was not shared. It is done, once all the callers are done,
+// with the error of the context of the last one.
+type flightContext struct {
+	context.Context
+	cancel context.CancelCauseFunc
+
+	mu      sync.Mutex
+	waiters map[*waiter]struct{}
+}
+
+func newFlightContext(ctx context.Context) *flightContext {
+	c := &flightContext{waiters: map[*waiter]struct{}{}}
+	c.Context, c.cancel = context.WithCancelCause(context.WithoutCancel(ctx))
+
+	return c
+}
+
+// Deadline implements context.Context interface, returning the latest deadline of the waiting callers.
+// There is no deadline, if any of them has none.
+func (c *flightContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var latest time.Time
+
+	for w := range c.waiters {
+		if w.deadline.IsZero() {
+			return time.Time{}, false
+		}
+
+		if w.deadline.After(latest) {
+			latest = w.deadline
+		}
+	}
+
+	return latest, !latest.IsZero()
+}
+
+// Err implements context.Context interface, returning the error of the context of the last caller,
+// so the execution ended by the deadline is reported as DeadlineExceeded.
+func (c *flightContext) Err() error {
+	if c.Context.Err() == nil {
+		return nil
+	}
+
+	return context.Cause(c.Context)
+}
+
+func (c *flightContext) add(w *waiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.waiters[w] = struct{}{}
+}
+
+// remove removes the waiter, and returns true, if it was the last one.
+func (c *flightContext) remove(w *waiter) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.waiters, w)
+
+	return len(c.waiters) == 0
+}
+
+// coalesce executes the request, unless an identical request is already in progress, in which case
+// it waits for that request and shares its result.
+//
+// The execution first waits for the admission, e.g. by the limiter of the backend, and then runs,
+// both within the context shared by the callers waiting for it, see flightContext. So it is not canceled,
+// when the caller which started it gives up, while others still wait, but it does not outlive all of them.
+// Each caller stops waiting when its own context is done. The caller, whose deadline is later than the one
+// which bounded the rejected admission, runs the admission again.
+func coalesce[Resp any](
+	ctx context.Context,
+	flights *flightGroup,
+	key string,
+	admit func(ctx context.Context) (release func(), err error),
+	exec func(ctx context.Context) (Resp, error),
+) (Resp, error) {
+	var empty Resp
+
+	if flights == nil {
+		release, err := admit(ctx)
+		if err != nil {
+			return empty, err
+		}
+		defer release()
+
+		return exec(ctx)
+	}
+
+	for {
+		f, w := flights.join(ctx, key, admit, func(ctx context.Context) (any, error) {
+			return exec(ctx)
+		})
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			if !flights.leave(key, f, w, ctx.Err()) {
+				return empty, status.FromContextError(ctx.Err()).Err()
+			}
+
+			// The execution waiting for the admission is rejected promptly, once its last caller leaves,
+			// so the rejection is returned, unless it has just been admitted.
+			select {
+			case <-f.done:
+			case <-f.admitted:
+				return empty, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+
+		if p, ok := f.val.(*flightPanic); ok {
+			panic(p)
+		}
+
+		if f.rejected && ctx.Err() == nil && admitsLater(ctx, f.rejectedDeadline) {
+			continue
+		}
+
+		if f.shared {
+			log.WithFields(csmlog.Fields{"key": key}).Info("Identical requests shared single execution")
+		}
+
+		if f.err != nil {
+			return empty, f.err
+		}
+
+		return f.val.(Resp), nil
+	}
+}
+
+// admitsLater checks if the deadline of the context is later than the deadline, which bounded the rejected admission.
+func admitsLater(ctx context.Context, rejectedDeadline time.Time) bool {
+	if rejectedDeadline.IsZero() {
+		return false
+	}
+
+	deadline, ok := ctx.Deadline()
+
+	return !ok || deadline.After(rejectedDeadline)
+}
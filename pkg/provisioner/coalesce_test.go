@@ -0,0 +1,480 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package provisioner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+)
+
+// slowDriver is a fake driver, which blocks bucket creation and access granting until it is released.
+type slowDriver struct {
+	fake.Driver
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (d *slowDriver) DriverCreateBucket(ctx context.Context, req *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	d.calls.Add(1)
+	<-d.release
+
+	return d.Driver.DriverCreateBucket(ctx, req)
+}
+
+func (d *slowDriver) DriverGrantBucketAccess(ctx context.Context, req *cosi.DriverGrantBucketAccessRequest) (*cosi.DriverGrantBucketAccessResponse, error) {
+	d.calls.Add(1)
+	<-d.release
+
+	return &cosi.DriverGrantBucketAccessResponse{AccountId: req.Name}, nil
+}
+
+// concurrently runs the request from n goroutines, and returns the channels of their responses and errors,
+// once all goroutines are about to send the request.
+func concurrently[Resp any](n int, send func() (Resp, error)) (chan Resp, chan error) {
+	var ready sync.WaitGroup
+
+	responses := make(chan Resp, n)
+	errs := make(chan error, n)
+
+	for range n {
+		ready.Add(1)
+
+		go func() {
+			ready.Done()
+
+			resp, err := send()
+			responses <- resp
+			errs <- err
+		}()
+	}
+
+	ready.Wait()
+
+	return responses, errs
+}
+
+// admitted admits all requests.
+func admitted(_ context.Context) (func(), error) {
+	return func() {}, nil
+}
+
+func TestCoalesce(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"SharesResult": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var (
+				flights flightGroup
+				calls   atomic.Int32
+			)
+
+			release := make(chan struct{})
+			exec := func(_ context.Context) (*int, error) {
+				calls.Add(1)
+				<-release
+
+				result := 42
+
+				return &result, nil
+			}
+
+			responses, errs := concurrently(10, func() (*int, error) {
+				return coalesce(ctx, &flights, "key", admitted, exec)
+			})
+
+			// let all goroutines join the execution in progress
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+
+			first := <-responses
+			require.NoError(t, <-errs)
+
+			for range 9 {
+				assert.Same(t, first, <-responses)
+				assert.NoError(t, <-errs)
+			}
+
+			assert.Equal(t, int32(1), calls.Load())
+		},
+		"SharesError": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			release := make(chan struct{})
+			exec := func(_ context.Context) (*int, error) {
+				<-release
+				return nil, errUnavailable
+			}
+
+			_, errs := concurrently(2, func() (*int, error) {
+				return coalesce(ctx, &flights, "key", admitted, exec)
+			})
+
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+
+			assert.ErrorIs(t, <-errs, errUnavailable)
+			assert.ErrorIs(t, <-errs, errUnavailable)
+		},
+		"WaiterStopsOnItsContext": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			release := make(chan struct{})
+			started := make(chan struct{})
+			exec := func(_ context.Context) (int, error) {
+				close(started)
+				<-release
+
+				return 42, nil
+			}
+
+			responses, errs := concurrently(1, func() (int, error) {
+				return coalesce(ctx, &flights, "key", admitted, exec)
+			})
+			<-started
+
+			waiterCtx, waiterCancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer waiterCancel()
+
+			_, err := coalesce(waiterCtx, &flights, "key", admitted, exec)
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+			// the execution is not affected by the waiter
+			close(release)
+			assert.Equal(t, 42, <-responses)
+			assert.NoError(t, <-errs)
+		},
+		"ExecutionOutlivesLeader": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			release := make(chan struct{})
+			started := make(chan struct{})
+			exec := func(ctx context.Context) (int, error) {
+				close(started)
+				<-release
+
+				// the execution is not canceled with the caller which started it
+				return 42, ctx.Err()
+			}
+
+			leaderCtx, leaderCancel := context.WithCancel(ctx)
+			_, leaderErrs := concurrently(1, func() (int, error) {
+				return coalesce(leaderCtx, &flights, "key", admitted, exec)
+			})
+			<-started
+
+			responses, errs := concurrently(1, func() (int, error) {
+				return coalesce(ctx, &flights, "key", admitted, exec)
+			})
+
+			leaderCancel()
+			assert.Equal(t, codes.Canceled, status.Code(<-leaderErrs))
+
+			close(release)
+			assert.Equal(t, 42, <-responses)
+			assert.NoError(t, <-errs)
+		},
+		"ExecutionHasLatestDeadline": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			release := make(chan struct{})
+			started := make(chan struct{})
+			deadlines := make(chan time.Time, 2)
+			exec := func(ctx context.Context) (int, error) {
+				close(started)
+
+				deadline, _ := ctx.Deadline()
+				deadlines <- deadline
+
+				<-release
+
+				deadline, _ = ctx.Deadline()
+				deadlines <- deadline
+
+				return 42, nil
+			}
+
+			leaderCtx, leaderCancel := context.WithTimeout(ctx, time.Minute)
+			defer leaderCancel()
+
+			_, leaderErrs := concurrently(1, func() (int, error) {
+				return coalesce(leaderCtx, &flights, "key", admitted, exec)
+			})
+			<-started
+
+			waiterCtx, waiterCancel := context.WithTimeout(ctx, time.Hour)
+			defer waiterCancel()
+
+			_, errs := concurrently(1, func() (int, error) {
+				return coalesce(waiterCtx, &flights, "key", admitted, exec)
+			})
+
+			leaderDeadline, _ := leaderCtx.Deadline()
+			assert.Equal(t, leaderDeadline, <-deadlines)
+
+			// let the waiter join the execution in progress
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+
+			waiterDeadline, _ := waiterCtx.Deadline()
+			assert.Equal(t, waiterDeadline, <-deadlines)
+			assert.NoError(t, <-leaderErrs)
+			assert.NoError(t, <-errs)
+		},
+		"ExecutionEndsWithLastCaller": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			started := make(chan struct{})
+			canceled := make(chan error, 1)
+			exec := func(ctx context.Context) (int, error) {
+				close(started)
+				<-ctx.Done()
+				canceled <- ctx.Err()
+
+				return 0, status.FromContextError(ctx.Err()).Err()
+			}
+
+			callerCtx, callerCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer callerCancel()
+
+			_, errs := concurrently(1, func() (int, error) {
+				return coalesce(callerCtx, &flights, "key", admitted, exec)
+			})
+			<-started
+
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(<-errs))
+			assert.ErrorIs(t, <-canceled, context.DeadlineExceeded)
+
+			// the next caller starts a new execution
+			result, err := coalesce(ctx, &flights, "key", admitted, func(_ context.Context) (int, error) { return 42, nil })
+			require.NoError(t, err)
+			assert.Equal(t, 42, result)
+		},
+		"RejectedAdmissionRunsAgain": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			admitting := make(chan struct{})
+			reject := make(chan struct{})
+			admissions := atomic.Int32{}
+			// the first admission is rejected, as it would not start before the deadline of the leader
+			admit := func(ctx context.Context) (func(), error) {
+				if admissions.Add(1) > 1 {
+					return func() {}, nil
+				}
+
+				close(admitting)
+				<-reject
+
+				return nil, status.Error(codes.ResourceExhausted, ErrBackendSaturated)
+			}
+
+			exec := func(_ context.Context) (int, error) { return 42, nil }
+
+			leaderCtx, leaderCancel := context.WithTimeout(ctx, time.Minute)
+			defer leaderCancel()
+
+			_, leaderErrs := concurrently(1, func() (int, error) {
+				return coalesce(leaderCtx, &flights, "key", admit, exec)
+			})
+			<-admitting
+
+			waiterCtx, waiterCancel := context.WithTimeout(ctx, time.Hour)
+			defer waiterCancel()
+
+			responses, errs := concurrently(1, func() (int, error) {
+				return coalesce(waiterCtx, &flights, "key", admit, exec)
+			})
+
+			time.Sleep(50 * time.Millisecond)
+			close(reject)
+
+			assert.Equal(t, codes.ResourceExhausted, status.Code(<-leaderErrs))
+
+			// the waiter with later deadline is admitted by itself
+			assert.Equal(t, 42, <-responses)
+			assert.NoError(t, <-errs)
+			assert.Equal(t, int32(2), admissions.Load())
+		},
+		"PanicsInCallers": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			var flights flightGroup
+
+			assert.PanicsWithValue(t, "driver bug", func() {
+				defer func() {
+					if p, ok := recover().(*flightPanic); ok {
+						assert.Contains(t, p.String(), "driver bug")
+						panic(p.value)
+					}
+				}()
+
+				_, _ = coalesce(ctx, &flights, "key", admitted, func(_ context.Context) (int, error) { panic("driver bug") })
+			})
+		},
+		"Nil": func(t *testing.T) {
+			result, err := coalesce(context.Background(), nil, "key", admitted, func(_ context.Context) (int, error) { return 42, nil })
+			require.NoError(t, err)
+			assert.Equal(t, 42, result)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestServerCoalescing(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"IdenticalRequests": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			d := &slowDriver{Driver: fake.Driver{FakeID: "slow"}, release: make(chan struct{})}
+
+			driverset := &Driverset{}
+			require.NoError(t, driverset.Add(d))
+
+			server := New(driverset)
+
+			createReq := &cosi.DriverCreateBucketRequest{Name: "bucket", Parameters: map[string]string{"id": "slow"}}
+			buckets, bucketErrs := concurrently(5, func() (*cosi.DriverCreateBucketResponse, error) {
+				return server.DriverCreateBucket(ctx, createReq)
+			})
+
+			grantReq := &cosi.DriverGrantBucketAccessRequest{BucketId: "slow-bucket", Name: "access", Parameters: map[string]string{"id": "slow"}}
+			accesses, accessErrs := concurrently(5, func() (*cosi.DriverGrantBucketAccessResponse, error) {
+				return server.DriverGrantBucketAccess(ctx, grantReq)
+			})
+
+			time.Sleep(50 * time.Millisecond)
+			close(d.release)
+
+			for range 5 {
+				require.NoError(t, <-bucketErrs)
+				assert.Equal(t, "slow-bucket", (<-buckets).BucketId)
+				require.NoError(t, <-accessErrs)
+				assert.Equal(t, "access", (<-accesses).AccountId)
+			}
+
+			// one execution of each operation
+			assert.Equal(t, int32(2), d.calls.Load())
+		},
+		"DifferentRequests": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			d := &slowDriver{Driver: fake.Driver{FakeID: "slow"}, release: make(chan struct{})}
+
+			driverset := &Driverset{}
+			require.NoError(t, driverset.Add(d))
+
+			server := New(driverset)
+
+			var names atomic.Int32
+
+			_, errs := concurrently(5, func() (*cosi.DriverGrantBucketAccessResponse, error) {
+				name := string(rune('a' + names.Add(1)))
+				req := &cosi.DriverGrantBucketAccessRequest{BucketId: "slow-bucket", Name: name, Parameters: map[string]string{"id": "slow"}}
+
+				return server.DriverGrantBucketAccess(ctx, req)
+			})
+
+			time.Sleep(50 * time.Millisecond)
+			close(d.release)
+
+			for range 5 {
+				require.NoError(t, <-errs)
+			}
+
+			assert.Equal(t, int32(5), d.calls.Load())
+		},
+		"SequentialRequests": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			d := &slowDriver{Driver: fake.Driver{FakeID: "slow"}, release: make(chan struct{})}
+			close(d.release)
+
+			driverset := &Driverset{}
+			require.NoError(t, driverset.Add(d))
+
+			server := New(driverset)
+
+			req := &cosi.DriverCreateBucketRequest{Name: "bucket", Parameters: map[string]string{"id": "slow"}}
+			for range 2 {
+				_, err := server.DriverCreateBucket(ctx, req)
+				require.NoError(t, err)
+			}
+
+			// completed requests are not shared
+			assert.Equal(t, int32(2), d.calls.Load())
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestFlightKey(t *testing.T) {
+	req := &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   "id-bucket",
+		Name:       "access",
+		Parameters: map[string]string{"id": "id", "accessMode": "ro", "ttl": "1h"},
+	}
+	key := flightKey("id", "GrantBucketAccess", req)
+
+	assert.True(t, strings.HasPrefix(key, "id/GrantBucketAccess/"))
+
+	for range 10 {
+		equal := &cosi.DriverGrantBucketAccessRequest{
+			BucketId:   "id-bucket",
+			Name:       "access",
+			Parameters: map[string]string{"ttl": "1h", "accessMode": "ro", "id": "id"},
+		}
+		assert.Equal(t, key, flightKey("id", "GrantBucketAccess", equal))
+	}
+
+	// requests differing only in the parameters are not identical
+	other := &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   "id-bucket",
+		Name:       "access",
+		Parameters: map[string]string{"id": "id", "accessMode": "rw", "ttl": "1h"},
+	}
+	assert.NotEqual(t, key, flightKey("id", "GrantBucketAccess", other))
+	assert.NotEqual(t, key, flightKey("id", "RevokeBucketAccess", req))
+}
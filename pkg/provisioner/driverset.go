@@ -49,6 +49,18 @@ func (ds *Driverset) Get(id string) (driver.Driver, error) {
 	}
 }
 
+// Range calls f sequentially for each driver present in the Driverset.
+// If f returns false, Range stops the iteration.
+func (ds *Driverset) Range(f func(driver.Driver) bool) {
+	ds.drivers.Range(func(_, d any) bool {
+		if d, ok := d.(driver.Driver); ok {
+			return f(d)
+		}
+
+		return true
+	})
+}
+
 // ErrDriverDuplicate indicates that the Driver is already present in driverset.
 type ErrDriverDuplicate struct {
 	ID string
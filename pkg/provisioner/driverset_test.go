@@ -135,6 +135,31 @@ func TestDriversetGet(t *testing.T) {
 	}
 }
 
+func TestDriversetRange(t *testing.T) {
+	t.Parallel()
+
+	driverset := &Driverset{}
+	driverset.drivers.Store("driver0", &fake.Driver{FakeID: "driver0"})
+	driverset.drivers.Store("driver1", &fake.Driver{FakeID: "driver1"})
+	driverset.drivers.Store("invalid", "value")
+
+	ids := []string{}
+	driverset.Range(func(d driver.Driver) bool {
+		ids = append(ids, d.ID())
+		return true
+	})
+
+	assert.ElementsMatch(t, []string{"driver0", "driver1"}, ids)
+
+	count := 0
+	driverset.Range(func(driver.Driver) bool {
+		count++
+		return false
+	})
+
+	assert.Equal(t, 1, count)
+}
+
 func TestErrDriverDuplicate(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,148 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package provisioner
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+const (
+	// ErrBackendSaturated is returned if the request could not start before its deadline, because of the limits of the backend.
+	ErrBackendSaturated = "too many requests to the object storage platform"
+
+	limiterMeterName = "Limiter"
+)
+
+// rejectedRequestsCounter counts the requests rejected because of the limits of the backend.
+var rejectedRequestsCounter, _ = otel.Meter(limiterMeterName).Int64Counter("cosi_limited_requests_rejected",
+	metric.WithDescription("Number of requests rejected, because the limits of the object storage platform were reached"))
+
+// limiter limits the rate and number of concurrent requests to the backend.
+// Requests over the limits wait for their turn, until their context is done.
+type limiter struct {
+	id string
+	// rate limits the rate of started requests. Nil if not limited.
+	rate *rate.Limiter
+	// inFlight holds a token for each request in progress. Nil if not limited.
+	inFlight chan struct{}
+}
+
+// newLimiter creates the limiter of the backend. It returns nil if the backend is not limited.
+func newLimiter(id string, limits driver.Limits) *limiter {
+	l := &limiter{id: id}
+
+	if limits.RequestsPerSecond > 0 {
+		burst := limits.Burst
+		if burst <= 0 {
+			burst = max(1, int(math.Ceil(limits.RequestsPerSecond)))
+		}
+
+		l.rate = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), burst)
+	}
+
+	if limits.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limits.MaxInFlight)
+	}
+
+	if l.rate == nil && l.inFlight == nil {
+		return nil
+	}
+
+	return l
+}
+
+// acquire waits until the request can be sent to the backend. The returned function must be called once the request is done.
+// Request which cannot start before its deadline is rejected with ResourceExhausted.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	// request which is already done must not take a free slot
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	if l.rate != nil {
+		// Wait fails immediately, if the request would not start before the deadline.
+		if err := l.rate.Wait(ctx); err != nil {
+			return nil, l.reject(ctx, "rate")
+		}
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+
+	case <-ctx.Done():
+		return nil, l.reject(ctx, "maxInFlight")
+	}
+}
+
+// reject logs and counts the rejected request, and returns its error.
+func (l *limiter) reject(ctx context.Context, limit string) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	log.WithFields(csmlog.Fields{"id": l.id, "limit": limit}).Warn("Request rejected, because the limit of object storage platform was reached")
+
+	rejectedRequestsCounter.Add(context.Background(), 1,
+		metric.WithAttributes(attribute.String("id", l.id), attribute.String("limit", limit)))
+
+	return status.Error(codes.ResourceExhausted, ErrBackendSaturated)
+}
+
+// limiters holds the limiters of the backends, created on the first request.
+type limiters struct {
+	mu       sync.Mutex
+	limiters map[string]*limiter
+}
+
+func newLimiters() *limiters {
+	return &limiters{limiters: map[string]*limiter{}}
+}
+
+// get returns the limiter of the driver. Nil limiter is returned for the drivers without limits, and by nil limiters.
+func (ls *limiters) get(d driver.Driver) *limiter {
+	if ls == nil {
+		return nil
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	l, ok := ls.limiters[d.ID()]
+	if !ok {
+		if limited, isLimited := d.(driver.Limited); isLimited {
+			l = newLimiter(d.ID(), limited.Limits())
+		}
+
+		ls.limiters[d.ID()] = l
+	}
+
+	return l
+}
@@ -0,0 +1,204 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package provisioner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver/fake"
+)
+
+// limitedDriver is a fake driver with limits, which blocks bucket creation until it is released.
+type limitedDriver struct {
+	fake.Driver
+	limits  driver.Limits
+	started chan struct{}
+	release chan struct{}
+}
+
+func (d *limitedDriver) Limits() driver.Limits {
+	return d.limits
+}
+
+func (d *limitedDriver) DriverCreateBucket(ctx context.Context, req *cosi.DriverCreateBucketRequest) (*cosi.DriverCreateBucketResponse, error) {
+	d.started <- struct{}{}
+	<-d.release
+
+	return d.Driver.DriverCreateBucket(ctx, req)
+}
+
+func newLimitedDriver(limits driver.Limits) *limitedDriver {
+	return &limitedDriver{
+		Driver:  fake.Driver{FakeID: "limited"},
+		limits:  limits,
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func TestNewLimiter(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"NotLimited": func(t *testing.T) {
+			assert.Nil(t, newLimiter("fake", driver.Limits{}))
+			assert.Nil(t, newLimiters().get(&fake.Driver{FakeID: "fake"}))
+			assert.Nil(t, (*limiters)(nil).get(&fake.Driver{FakeID: "fake"}))
+		},
+		"DefaultBurst": func(t *testing.T) {
+			l := newLimiter("fake", driver.Limits{RequestsPerSecond: 2.5})
+			require.NotNil(t, l)
+			assert.Equal(t, 3, l.rate.Burst())
+			assert.Nil(t, l.inFlight)
+
+			l = newLimiter("fake", driver.Limits{RequestsPerSecond: 0.1})
+			assert.Equal(t, 1, l.rate.Burst())
+		},
+		"ConfiguredLimits": func(t *testing.T) {
+			l := newLimiters().get(newLimitedDriver(driver.Limits{MaxInFlight: 4, RequestsPerSecond: 10, Burst: 2}))
+			require.NotNil(t, l)
+			assert.Equal(t, 2, l.rate.Burst())
+			assert.Equal(t, 4, cap(l.inFlight))
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"MaxInFlight": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			l := newLimiter("fake", driver.Limits{MaxInFlight: 1})
+
+			release, err := l.acquire(ctx)
+			require.NoError(t, err)
+
+			// the queued request is rejected at its deadline
+			shortCtx, shortCancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer shortCancel()
+
+			_, err = l.acquire(shortCtx)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Equal(t, ErrBackendSaturated, status.Convert(err).Message())
+
+			// the queued request starts, once the request in progress is done
+			queued := make(chan error)
+			go func() {
+				_, err := l.acquire(ctx)
+				queued <- err
+			}()
+
+			release()
+			assert.NoError(t, <-queued)
+		},
+		"RateBoundedByDeadline": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			l := newLimiter("fake", driver.Limits{RequestsPerSecond: 1, Burst: 1})
+
+			_, err := l.acquire(ctx)
+			require.NoError(t, err)
+
+			// next token is available in a second, after the deadline, so the request is rejected immediately
+			shortCtx, shortCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer shortCancel()
+
+			start := time.Now()
+			_, err = l.acquire(shortCtx)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Less(t, time.Since(start), 50*time.Millisecond)
+		},
+		"Canceled": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			l := newLimiter("fake", driver.Limits{MaxInFlight: 1})
+
+			_, err := l.acquire(ctx)
+			require.NoError(t, err)
+
+			canceledCtx, cancelQueued := context.WithCancel(ctx)
+			cancelQueued()
+
+			_, err = l.acquire(canceledCtx)
+			assert.Equal(t, codes.Canceled, status.Code(err))
+		},
+		"DoneRequestDoesNotTakeFreeSlot": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			cancel()
+
+			l := newLimiter("fake", driver.Limits{MaxInFlight: 1})
+
+			_, err := l.acquire(ctx)
+			assert.Equal(t, codes.Canceled, status.Code(err))
+			assert.Empty(t, l.inFlight)
+		},
+		"Nil": func(t *testing.T) {
+			release, err := (*limiter)(nil).acquire(context.Background())
+			require.NoError(t, err)
+			release()
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestServerLimits(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	d := newLimitedDriver(driver.Limits{MaxInFlight: 2})
+
+	driverset := &Driverset{}
+	require.NoError(t, driverset.Add(d))
+
+	server := New(driverset)
+
+	req := &cosi.DriverCreateBucketRequest{Name: "bucket", Parameters: map[string]string{"id": "limited"}}
+
+	done := make(chan error, 2)
+	for _, name := range []string{"first", "second"} {
+		go func() {
+			_, err := server.DriverCreateBucket(ctx, &cosi.DriverCreateBucketRequest{Name: name, Parameters: req.Parameters})
+			done <- err
+		}()
+	}
+
+	<-d.started
+	<-d.started
+
+	// both slots are taken, so the request is rejected at its deadline, without reaching the driver
+	for range DefaultFailureThreshold {
+		shortCtx, shortCancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		_, err := server.DriverCreateBucket(shortCtx, req)
+		shortCancel()
+
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	}
+
+	// rejected requests do not open the circuit breaker
+	assert.Equal(t, BreakerClosed, server.breakers.get(d).state)
+
+	close(d.release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-done)
+	assert.Empty(t, d.started)
+}
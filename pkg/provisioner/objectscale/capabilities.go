@@ -0,0 +1,63 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	obsConfig "github.com/dell/cosi/pkg/config"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+const (
+	// Platform is the type of the object storage platform supported by the driver.
+	Platform = "objectscale"
+
+	// Optional features reported in the capabilities of the driver.
+	FeatureEmptyBucket           = "emptyBucket"
+	FeaturePolicyTemplates       = "policyTemplates"
+	FeatureAllowedSourceCIDRs    = "allowedSourceCIDRs"
+	FeatureGroupAccessManagement = "groupAccessManagement"
+	FeatureCrossNamespaces       = "crossNamespaces"
+	FeatureIAMAuthentication     = "iamAuthentication"
+	FeatureExpiringCredentials   = "expiringCredentials"
+	FeatureOrphanReconciliation  = "orphanReconciliation"
+	FeatureOrphanRemoval         = "orphanRemoval"
+	FeatureLimits                = "limits"
+)
+
+// Capabilities reports the S3 protocol, and the optional features enabled by the configuration.
+// Time-limited credentials are always supported.
+func (s *Server) Capabilities() driver.Capabilities {
+	features := []string{}
+
+	for _, feature := range []struct {
+		name    string
+		enabled bool
+	}{
+		{FeatureEmptyBucket, s.emptyBucket},
+		{FeaturePolicyTemplates, len(s.policyTemplates) > 0},
+		{FeatureAllowedSourceCIDRs, len(s.allowedSourceCIDRs) > 0},
+		{FeatureGroupAccessManagement, s.accessManagement == obsConfig.ObjectscaleAccessManagementGroup},
+		{FeatureCrossNamespaces, len(s.crossNamespaceIAMClients) > 0},
+		{FeatureIAMAuthentication, s.oidcProvider != nil},
+		{FeatureExpiringCredentials, true},
+		{FeatureOrphanReconciliation, s.reconcileInterval > 0},
+		{FeatureOrphanRemoval, s.reconcileInterval > 0 && s.removeOrphans},
+		{FeatureLimits, s.limits != driver.Limits{}},
+	} {
+		if feature.enabled {
+			features = append(features, feature.name)
+		}
+	}
+
+	return driver.Capabilities{
+		Platform:  Platform,
+		Protocols: []string{"s3"},
+		Features:  features,
+	}
+}
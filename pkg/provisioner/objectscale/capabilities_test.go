@@ -0,0 +1,75 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *Server
+		features []string
+	}{
+		{
+			name:     "default configuration",
+			server:   &Server{accessManagement: obsConfig.ObjectscaleAccessManagementBucketPolicy},
+			features: []string{FeatureExpiringCredentials},
+		},
+		{
+			name: "all features",
+			server: &Server{
+				emptyBucket:              true,
+				policyTemplates:          map[string]*policyTemplate{"read-only": {}},
+				allowedSourceCIDRs:       []string{"10.0.0.0/8"},
+				accessManagement:         obsConfig.ObjectscaleAccessManagementGroup,
+				crossNamespaceIAMClients: map[string]func(ctx context.Context) (IAM, error){"other": nil},
+				oidcProvider:             &oidcProvider{},
+				reconcileInterval:        time.Hour,
+				removeOrphans:            true,
+				limits:                   driver.Limits{MaxInFlight: 10},
+			},
+			features: []string{
+				FeatureEmptyBucket,
+				FeaturePolicyTemplates,
+				FeatureAllowedSourceCIDRs,
+				FeatureGroupAccessManagement,
+				FeatureCrossNamespaces,
+				FeatureIAMAuthentication,
+				FeatureExpiringCredentials,
+				FeatureOrphanReconciliation,
+				FeatureOrphanRemoval,
+				FeatureLimits,
+			},
+		},
+		{
+			name:     "orphans are not removed without reconciliation",
+			server:   &Server{removeOrphans: true},
+			features: []string{FeatureExpiringCredentials},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, driver.Capabilities{
+				Platform:  Platform,
+				Protocols: []string{"s3"},
+				Features:  tc.features,
+			}, tc.server.Capabilities())
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+const (
+	// AllowedSourceCIDRsParam is the BucketAccessClass parameter with comma-separated list of source CIDRs,
+	// from which the minted credentials can be used. It can only narrow the default from the connection configuration.
+	AllowedSourceCIDRsParam = "allowedSourceCidrs"
+
+	sourceIPConditionOperator = "IpAddress"
+	sourceIPConditionKey      = "aws:SourceIp"
+)
+
+// parseSourceCIDRs validates the list of CIDRs and returns them in the canonical form.
+func parseSourceCIDRs(cidrs []string) ([]string, error) {
+	parsed := make([]string, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source CIDR %s: %w", cidr, err)
+		}
+
+		parsed = append(parsed, ipNet.String())
+	}
+
+	return parsed, nil
+}
+
+// sourceCIDRs returns source CIDRs allowed for the bucket access.
+// The BucketAccessClass parameter is intersected with the connection default, so it cannot widen
+// the restriction configured for the connection.
+func (s *Server) sourceCIDRs(parameters map[string]string) ([]string, error) {
+	raw := strings.TrimSpace(parameters[AllowedSourceCIDRsParam])
+	if raw == "" {
+		return s.allowedSourceCIDRs, nil
+	}
+
+	cidrs, err := parseSourceCIDRs(strings.Split(raw, ","))
+	if err != nil {
+		return nil, err
+	}
+
+	// empty list would remove the restriction
+	if len(cidrs) == 0 {
+		return nil, fmt.Errorf("no source CIDRs in %q", raw)
+	}
+
+	if len(s.allowedSourceCIDRs) == 0 {
+		return cidrs, nil
+	}
+
+	return intersectCIDRs(cidrs, s.allowedSourceCIDRs)
+}
+
+// intersectCIDRs returns the CIDRs, which are contained in both lists of canonical CIDRs.
+// Two CIDRs either do not overlap, or one of them contains the other, so the intersection of overlapping
+// CIDRs is the narrower one.
+func intersectCIDRs(cidrs, allowed []string) ([]string, error) {
+	var intersection []string
+
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source CIDR %s: %w", cidr, err)
+		}
+
+		for _, a := range allowed {
+			allowedPrefix, err := netip.ParsePrefix(a)
+			if err != nil {
+				return nil, fmt.Errorf("invalid source CIDR %s: %w", a, err)
+			}
+
+			if !prefix.Overlaps(allowedPrefix) {
+				continue
+			}
+
+			narrower := prefix
+			if allowedPrefix.Bits() > prefix.Bits() {
+				narrower = allowedPrefix
+			}
+
+			if !slices.Contains(intersection, narrower.String()) {
+				intersection = append(intersection, narrower.String())
+			}
+		}
+	}
+
+	if len(intersection) == 0 {
+		return nil, errors.New("source CIDRs are outside of the CIDRs allowed by the connection")
+	}
+
+	return intersection, nil
+}
+
+// restrictSourceIP adds the aws:SourceIp condition to every statement, if any CIDRs are provided.
+func restrictSourceIP(statements []policy.StatementEntry, cidrs []string) {
+	if len(cidrs) == 0 {
+		return
+	}
+
+	for i := range statements {
+		statements[i].AddCondition(sourceIPConditionOperator, sourceIPConditionKey, cidrs...)
+	}
+}
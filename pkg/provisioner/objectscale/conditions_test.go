@@ -0,0 +1,108 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+func TestSourceCIDRs(t *testing.T) {
+	server := Server{allowedSourceCIDRs: []string{"172.16.0.0/12", "10.0.0.0/8"}}
+	unrestricted := Server{}
+
+	tests := []struct {
+		name       string
+		server     Server
+		parameters map[string]string
+		expected   []string
+		wantErr    bool
+	}{
+		{
+			name:       "connection default",
+			server:     server,
+			parameters: map[string]string{},
+			expected:   []string{"172.16.0.0/12", "10.0.0.0/8"},
+		},
+		{
+			name:       "empty parameter",
+			server:     server,
+			parameters: map[string]string{AllowedSourceCIDRsParam: " "},
+			expected:   []string{"172.16.0.0/12", "10.0.0.0/8"},
+		},
+		{
+			name:       "parameter without connection default",
+			server:     unrestricted,
+			parameters: map[string]string{AllowedSourceCIDRsParam: "10.1.2.3/8,, 2001:db8::1/32"},
+			expected:   []string{"10.0.0.0/8", "2001:db8::/32"},
+		},
+		{
+			name:       "parameter narrowing connection default",
+			server:     server,
+			parameters: map[string]string{AllowedSourceCIDRsParam: "10.1.0.0/16,172.16.1.0/24"},
+			expected:   []string{"10.1.0.0/16", "172.16.1.0/24"},
+		},
+		{
+			name:       "parameter wider than connection default",
+			server:     server,
+			parameters: map[string]string{AllowedSourceCIDRsParam: "0.0.0.0/0, 192.168.0.0/16"},
+			expected:   []string{"172.16.0.0/12", "10.0.0.0/8"},
+		},
+		{
+			name:       "parameter outside of connection default",
+			server:     server,
+			parameters: map[string]string{AllowedSourceCIDRsParam: "192.168.0.0/16,2001:db8::/32"},
+			wantErr:    true,
+		},
+		{
+			name:       "no CIDRs in parameter",
+			server:     unrestricted,
+			parameters: map[string]string{AllowedSourceCIDRsParam: " , ,"},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid CIDR",
+			server:     server,
+			parameters: map[string]string{AllowedSourceCIDRsParam: "10.0.0.0/8,invalid"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cidrs, err := tt.server.sourceCIDRs(tt.parameters)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cidrs)
+		})
+	}
+}
+
+func TestRestrictSourceIP(t *testing.T) {
+	statements := []policy.StatementEntry{{Sid: PolicySid}, {Sid: PolicySid}}
+
+	restrictSourceIP(statements, nil)
+
+	for _, statement := range statements {
+		assert.Nil(t, statement.Condition)
+	}
+
+	restrictSourceIP(statements, []string{"10.0.0.0/8"})
+
+	for _, statement := range statements {
+		assert.Equal(t, policy.ConditionValues{"10.0.0.0/8"}, statement.Condition["IpAddress"]["aws:SourceIp"])
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// UserNamespaceParam is the BucketAccessClass parameter selecting the namespace, in which the user is created.
+	// The namespace must be either the namespace of the connection, or one of its cross namespaces.
+	UserNamespaceParam = "userNamespace"
+
+	// principalPrefix is the prefix of the ObjectScale IAM principal.
+	principalPrefix = "urn:ecs:iam::"
+)
+
+// newCrossNamespaceIAMClients creates IAM client factories for the cross namespaces, indexed by namespace.
+// The factories share the authenticator with the factory of the connection namespace.
+func newCrossNamespaceIAMClients(
+	factory *IAMClientFactory,
+	namespaces []string,
+) (map[string]func(context.Context) (IAM, error), error) {
+	clients := make(map[string]func(context.Context) (IAM, error), len(namespaces))
+
+	for _, namespace := range namespaces {
+		if namespace == "" {
+			return nil, errors.New("empty cross namespace")
+		}
+
+		if _, ok := clients[namespace]; ok || namespace == factory.namespace {
+			return nil, fmt.Errorf("duplicate cross namespace %s", namespace)
+		}
+
+		clients[namespace] = factory.forNamespace(namespace).getIAMClient
+	}
+
+	return clients, nil
+}
+
+// userNamespace returns the namespace, in which the user for the bucket access is created.
+func (s *Server) userNamespace(parameters map[string]string) (string, error) {
+	namespace := strings.TrimSpace(parameters[UserNamespaceParam])
+	if namespace == "" {
+		return s.namespace, nil
+	}
+
+	if !s.isUserNamespace(namespace) {
+		return "", fmt.Errorf("namespace %s is not configured for cross-namespace access", namespace)
+	}
+
+	return namespace, nil
+}
+
+// isUserNamespace checks if users can be managed by the driver in the namespace.
+func (s *Server) isUserNamespace(namespace string) bool {
+	if namespace == s.namespace {
+		return true
+	}
+
+	_, ok := s.crossNamespaceIAMClients[namespace]
+
+	return ok
+}
+
+// userNamespaces returns all namespaces, in which users are managed by the driver.
+func (s *Server) userNamespaces() []string {
+	namespaces := make([]string, 0, len(s.crossNamespaceIAMClients))
+	for namespace := range s.crossNamespaceIAMClients {
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+
+	return append([]string{s.namespace}, namespaces...)
+}
+
+// iamClientFor returns IAM client for the namespace.
+func (s *Server) iamClientFor(ctx context.Context, namespace string) (IAM, error) {
+	if namespace == s.namespace {
+		return s.iamClient(ctx)
+	}
+
+	iamClient, ok := s.crossNamespaceIAMClients[namespace]
+	if !ok {
+		return nil, fmt.Errorf("namespace %s is not configured for cross-namespace access", namespace)
+	}
+
+	return iamClient(ctx)
+}
+
+// accountID builds the account ID of the bucket access.
+// Users in the namespace of the connection are identified by the name, for compatibility with existing accesses,
+// while users in other namespaces are identified by their principal.
+func (s *Server) accountID(userName, namespace string) string {
+	if namespace == s.namespace {
+		return userName
+	}
+
+	return BuildPrincipalString(userName, namespace)
+}
+
+// parseAccountID returns the user name and namespace from the account ID built by accountID.
+func (s *Server) parseAccountID(accountID string) (string, string, error) {
+	if !strings.HasPrefix(accountID, principalPrefix) {
+		return accountID, s.namespace, nil
+	}
+
+	namespace, userName, ok := strings.Cut(strings.TrimPrefix(accountID, principalPrefix), ":user/")
+	if !ok || namespace == "" || userName == "" {
+		return "", "", fmt.Errorf("invalid account id %s", accountID)
+	}
+
+	if !s.isUserNamespace(namespace) {
+		return "", "", fmt.Errorf("namespace %s is not configured for cross-namespace access", namespace)
+	}
+
+	return userName, namespace, nil
+}
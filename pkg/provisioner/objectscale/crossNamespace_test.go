@@ -0,0 +1,158 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+)
+
+const testCrossNamespace = "cross-namespace"
+
+func TestNewCrossNamespaceIAMClients(t *testing.T) {
+	factory := &IAMClientFactory{namespace: testNamespace}
+
+	tests := []struct {
+		name       string
+		namespaces []string
+		wantErr    bool
+	}{
+		{
+			name:       "no cross namespaces",
+			namespaces: nil,
+		},
+		{
+			name:       "valid cross namespaces",
+			namespaces: []string{"first", "second"},
+		},
+		{
+			name:       "empty namespace",
+			namespaces: []string{""},
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate namespace",
+			namespaces: []string{"first", "first"},
+			wantErr:    true,
+		},
+		{
+			name:       "connection namespace",
+			namespaces: []string{testNamespace},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients, err := newCrossNamespaceIAMClients(factory, tt.namespaces)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, clients, len(tt.namespaces))
+			// factory of the connection namespace must not be modified
+			assert.Equal(t, testNamespace, factory.namespace)
+		})
+	}
+}
+
+func TestUserNamespace(t *testing.T) {
+	server := testCrossNamespaceServer(nil, nil)
+
+	namespace, err := server.userNamespace(map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, testNamespace, namespace)
+
+	namespace, err = server.userNamespace(map[string]string{UserNamespaceParam: testCrossNamespace})
+	assert.NoError(t, err)
+	assert.Equal(t, testCrossNamespace, namespace)
+
+	_, err = server.userNamespace(map[string]string{UserNamespaceParam: "unknown"})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{testNamespace, testCrossNamespace}, server.userNamespaces())
+}
+
+func TestIAMClientFor(t *testing.T) {
+	own := omocks.NewIAM(t)
+	cross := omocks.NewIAM(t)
+	server := testCrossNamespaceServer(own, cross)
+
+	client, err := server.iamClientFor(context.Background(), testNamespace)
+	assert.NoError(t, err)
+	assert.Same(t, own, client)
+
+	client, err = server.iamClientFor(context.Background(), testCrossNamespace)
+	assert.NoError(t, err)
+	assert.Same(t, cross, client)
+
+	_, err = server.iamClientFor(context.Background(), "unknown")
+	assert.Error(t, err)
+}
+
+func TestAccountID(t *testing.T) {
+	server := testCrossNamespaceServer(nil, nil)
+
+	tests := []struct {
+		name      string
+		userName  string
+		namespace string
+		accountID string
+	}{
+		{
+			name:      "connection namespace",
+			userName:  "user",
+			namespace: testNamespace,
+			accountID: "user",
+		},
+		{
+			name:      "cross namespace",
+			userName:  "user",
+			namespace: testCrossNamespace,
+			accountID: "urn:ecs:iam::cross-namespace:user/user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountID := server.accountID(tt.userName, tt.namespace)
+			assert.Equal(t, tt.accountID, accountID)
+
+			userName, namespace, err := server.parseAccountID(accountID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.userName, userName)
+			assert.Equal(t, tt.namespace, namespace)
+		})
+	}
+
+	for _, invalid := range []string{
+		"urn:ecs:iam::cross-namespace:role/user",
+		"urn:ecs:iam:::user/user",
+		"urn:ecs:iam::unknown:user/user",
+	} {
+		_, _, err := server.parseAccountID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func testCrossNamespaceServer(own, cross IAM) *Server {
+	return &Server{
+		namespace: testNamespace,
+		iamClient: func(context.Context) (IAM, error) { return own, nil },
+		crossNamespaceIAMClients: map[string]func(context.Context) (IAM, error){
+			testCrossNamespace: func(context.Context) (IAM, error) { return cross, nil },
+		},
+	}
+}
@@ -36,14 +36,14 @@ func (s *Server) DriverCreateBucket(ctx context.Context,
 	ctx, span := otel.Tracer(CreateBucketTraceName).Start(ctx, "DriverCreateBucket")
 	defer span.End()
 
-	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	ctx, cancel := withTimeout(ctx, s.timeouts.createBucket)
 	defer cancel()
 
 	log.Infof("Creating Bucket %s", req.GetName())
 	createParams := &model.CreateBucketRequestParams{}
 	err := createParams.ParseFrom(req.GetParameters())
 	if err != nil {
-		return nil, logAndTraceError(span, "failed parsing parameters", err, codes.Internal)
+		return nil, logAndTraceError(ctx, span, "failed parsing parameters", err, codes.InvalidArgument)
 	}
 
 	// check rg if user input replicationGroup value
@@ -51,7 +51,7 @@ func (s *Server) DriverCreateBucket(ctx context.Context,
 	if len(createParams.ReplicationGroup) > 0 {
 		vPools, err := s.mgmtClient.VPools().List(ctx)
 		if err != nil {
-			return nil, logAndTraceError(span, "failed listing replication groups", err, codes.Internal)
+			return nil, logAndTraceError(ctx, span, "failed listing replication groups", err, codes.Internal)
 		}
 		rgExists := false
 		for _, vPool := range vPools {
@@ -62,13 +62,13 @@ func (s *Server) DriverCreateBucket(ctx context.Context,
 			}
 		}
 		if !rgExists {
-			return nil, logAndTraceError(span, "replication group not found", err, codes.NotFound, "replicationGroup", createParams.ReplicationGroup)
+			return nil, logAndTraceError(ctx, span, "replication group not found", err, codes.NotFound, "replicationGroup", createParams.ReplicationGroup)
 		}
 	}
 
 	existingBucket, err := getBucket(ctx, s, req.GetName(), map[string]string{"namespace": s.namespace})
 	if err != nil && !errors.Is(err, model.ErrParameterNotFound) {
-		return nil, logAndTraceError(span, "error finding bucket", err, codes.Internal, "namespace", s.namespace, "bucket", req.GetName())
+		return nil, logAndTraceError(ctx, span, "error finding bucket", err, codes.Internal, "namespace", s.namespace, "bucket", req.GetName())
 	} else if err == nil && existingBucket != nil {
 		return &cosi.DriverCreateBucketResponse{
 			BucketId: strings.Join([]string{s.backendID, existingBucket.Name}, "-"),
@@ -89,7 +89,7 @@ func (s *Server) DriverCreateBucket(ctx context.Context,
 
 	bucket, err := s.mgmtClient.Buckets().Create(ctx, toBeCreatedBucket)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed to create bucket", err, codes.Internal, "namespace", s.namespace, "bucket", req.GetName())
+		return nil, logAndTraceError(ctx, span, "failed to create bucket", err, codes.Internal, "namespace", s.namespace, "bucket", req.GetName())
 	}
 
 	log.Infof("Successfully created bucket %s in namespace %s", req.GetName(), s.namespace)
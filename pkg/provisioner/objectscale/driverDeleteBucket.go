@@ -22,9 +22,12 @@ func (s *Server) DriverDeleteBucket(ctx context.Context,
 	ctx, span := otel.Tracer(CreateBucketTraceName).Start(ctx, "DriverDeleteBucket")
 	defer span.End()
 
+	ctx, cancel := withTimeout(ctx, s.timeouts.deleteBucket)
+	defer cancel()
+
 	bucketName, err := GetBucketNameFromID(req.GetBucketId())
 	if err != nil {
-		return nil, logAndTraceError(span, "invalid bucket name", err, codes.InvalidArgument)
+		return nil, logAndTraceError(ctx, span, "invalid bucket name", err, codes.InvalidArgument)
 	}
 	log.Infof("Deleting Bucket %s", bucketName)
 	parameters := map[string]string{}
@@ -32,18 +35,30 @@ func (s *Server) DriverDeleteBucket(ctx context.Context,
 
 	bucketExists, err := checkBucketExistence(ctx, s, bucketName, parameters)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
+		return nil, logAndTraceError(ctx, span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
 	}
 
 	if bucketExists {
 		err := s.mgmtClient.Buckets().Delete(ctx, bucketName, parameters)
 		if err != nil {
-			return nil, logAndTraceError(span, "failed deleting bucket", err, codes.Internal, "bucket", bucketName)
+			return nil, logAndTraceError(ctx, span, "failed deleting bucket", err, codes.Internal, "bucket", bucketName)
 		}
 	} else {
 		log.Warnf("Bucket %s does not exist", bucketName)
 	}
 
+	// Groups are cleaned up after the bucket is deleted, so they are retried on failure with the whole request.
+	if s.groupAccessManagement() {
+		iamClient, err := s.iamClient(ctx)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed getting IAM client", err, codes.Internal, "bucket", bucketName)
+		}
+
+		if err := s.deleteBucketGroups(ctx, iamClient, bucketName); err != nil {
+			return nil, logAndTraceError(ctx, span, "failed deleting bucket groups", err, codes.Internal, "bucket", bucketName)
+		}
+	}
+
 	log.Infof("Deleted Bucket %s", bucketName)
 	return &cosi.DriverDeleteBucketResponse{}, nil
 }
@@ -9,11 +9,17 @@
 package objectscale
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/cosi/pkg/config"
 	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
 	"github.com/dell/goobjectscale/pkg/client/api/mocks"
 	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +44,10 @@ func TestServerDriverDeleteBucket(t *testing.T) {
 		// testing errors
 		"BucketDeletionFailed": testDriverDeleteBucketBucketDeletionFailed,
 		"GetBucketFailed":      testDriverDeleteGetBucketFailed,
+		// group access management
+		"BucketGroupsDeleted":              testDriverDeleteBucketGroupsDeleted,
+		"BucketGroupsDeletionFailed":       testDriverDeleteBucketGroupsDeletionFailed,
+		"BucketGroupsUnableToGetIAMClient": testDriverDeleteBucketGroupsUnableToGetIAMClient,
 	} {
 		fn := fn
 
@@ -140,3 +150,107 @@ func testDriverDeleteBucketBucketDoesNotExist(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 }
+
+// testDriverDeleteBucketGroupsDeleted tests that groups and policies created for the bucket are deleted
+// together with the bucket, when access is managed by IAM groups.
+func testDriverDeleteBucketGroupsDeleted(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, model.ErrParameterNotFound).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock).Once()
+
+	readWriteGroup := BuildGroupName(testBucketName, AccessModeReadWrite)
+	readOnlyGroup := BuildGroupName(testBucketName, AccessModeReadOnly)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("DetachGroupPolicy", mock.Anything, &iam.DetachGroupPolicyInput{
+		GroupName: aws.String(readWriteGroup),
+		PolicyArn: aws.String(BuildPolicyARN(readWriteGroup, testNamespace)),
+	}).Return(&iam.DetachGroupPolicyOutput{}, nil).Once()
+	// user of the access not revoked yet is removed, so the group can be deleted
+	iamMock.On("GetGroup", mock.Anything, &iam.GetGroupInput{GroupName: aws.String(readWriteGroup)}).Return(&iam.GetGroupOutput{
+		Users: []types.User{{UserName: aws.String("member")}},
+	}, nil).Once()
+	iamMock.On("RemoveUserFromGroup", mock.Anything, &iam.RemoveUserFromGroupInput{
+		GroupName: aws.String(readWriteGroup),
+		UserName:  aws.String("member"),
+	}).Return(&iam.RemoveUserFromGroupOutput{}, nil).Once()
+	iamMock.On("DeleteGroup", mock.Anything, &iam.DeleteGroupInput{GroupName: aws.String(readWriteGroup)}).Return(&iam.DeleteGroupOutput{}, nil).Once()
+	iamMock.On("DeletePolicy", mock.Anything, &iam.DeletePolicyInput{
+		PolicyArn: aws.String(BuildPolicyARN(readWriteGroup, testNamespace)),
+	}).Return(&iam.DeletePolicyOutput{}, nil).Once()
+	// group for the read-only access was never created
+	iamMock.On("DetachGroupPolicy", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("GetGroup", mock.Anything, &iam.GetGroupInput{GroupName: aws.String(readOnlyGroup)}).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("DeleteGroup", mock.Anything, &iam.DeleteGroupInput{GroupName: aws.String(readOnlyGroup)}).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("DeletePolicy", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return iamMock, nil },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	res, err := server.DriverDeleteBucket(ctx, testBucketDeletionRequest)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverDeleteBucketGroupsDeletionFailed(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, model.ErrParameterNotFound).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock).Once()
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("DetachGroupPolicy", mock.Anything, mock.Anything).Return(&iam.DetachGroupPolicyOutput{}, nil).Once()
+	iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+	iamMock.On("DeleteGroup", mock.Anything, mock.Anything).Return(nil, &types.DeleteConflictException{}).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return iamMock, nil },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	_, err := server.DriverDeleteBucket(ctx, testBucketDeletionRequest)
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "failed deleting bucket groups", status.Convert(err).Message())
+}
+
+func testDriverDeleteBucketGroupsUnableToGetIAMClient(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, model.ErrParameterNotFound).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return nil, errors.New("failed") },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	_, err := server.DriverDeleteBucket(ctx, testBucketDeletionRequest)
+
+	assert.ErrorIs(t, err, status.Error(codes.Internal, "failed getting IAM client"))
+}
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dell/cosi/pkg/provisioner/policy"
 	"go.opentelemetry.io/otel"
@@ -33,16 +34,39 @@ func (s *Server) DriverGrantBucketAccess(ctx context.Context,
 	ctx, span := otel.Tracer(CreateBucketTraceName).Start(ctx, "DriverGrantBucketAccess")
 	defer span.End()
 
+	ctx, cancel := withTimeout(ctx, s.timeouts.grantBucketAccess)
+	defer cancel()
+
 	// Get bucket name from bucketID.
 	bucketName, err := GetBucketNameFromID(req.GetBucketId())
 	if err != nil {
-		return nil, logAndTraceError(span, "invalid bucket name", err, codes.InvalidArgument)
+		return nil, logAndTraceError(ctx, span, "invalid bucket name", err, codes.InvalidArgument)
+	}
+
+	// Workloads using IAM authentication assume a role with their ServiceAccount token, instead of using static keys.
+	// Modes, which cannot be combined with it, are refused here, before anything is created: the role is created
+	// in the connection namespace, is never a member of the bucket group, and has no keys to expire.
+	iamAuthentication, err := s.authenticationType(req)
+	if err != nil {
+		return nil, logAndTraceError(ctx, span, "invalid authentication type", err, codes.InvalidArgument, "bucket", bucketName)
+	}
+
+	if s.groupAccessManagement() {
+		if err := validateGroupAccessParameters(req.Parameters); err != nil {
+			return nil, logAndTraceError(ctx, span, "invalid parameters", err, codes.InvalidArgument, "bucket", bucketName)
+		}
+	}
+
+	// Users can be created in a namespace other than the namespace of the bucket.
+	userNamespace, err := s.userNamespace(req.Parameters)
+	if err != nil {
+		return nil, logAndTraceError(ctx, span, "invalid user namespace", err, codes.InvalidArgument, "bucket", bucketName)
 	}
 
 	log.Infof("Creating Bucket Access %s for bucket %s", req.Name, bucketName)
-	iamClient, err := s.iamClient(ctx)
+	iamClient, err := s.iamClientFor(ctx, userNamespace)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed getting IAM client", err, codes.Internal, "bucket", bucketName)
+		return nil, logAndTraceError(ctx, span, "failed getting IAM client", err, codes.Internal, "bucket", bucketName)
 	}
 	// Construct common parameters for bucket requests.
 	parameters := make(map[string]string)
@@ -52,98 +76,220 @@ func (s *Server) DriverGrantBucketAccess(ctx context.Context,
 	// Check if bucket for granting access exists.
 	bucketExists, err := checkBucketExistence(ctx, s, bucketName, parameters)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
+		return nil, logAndTraceError(ctx, span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
 	}
 
 	if !bucketExists {
-		return nil, logAndTraceError(span, "bucket not found", err, codes.NotFound, "bucket", bucketName)
+		return nil, logAndTraceError(ctx, span, "bucket not found", err, codes.NotFound, "bucket", bucketName)
 	}
 
-	// This flow below will check for user existence; if user does not exist, it will create one. It will only fail
-	// in case of an unknown error, e.g. network issues, to adhere to idempotency requirement.
-	userName := BuildUsername(s.namespace, req.Name)
-	var user *types.User
-	result, err := iamClient.GetUser(ctx, &iam.GetUserInput{
-		UserName: aws.String(userName),
-	})
+	userName, err := s.buildUsername(userNamespace, req.Name)
 	if err != nil {
-		var apiError smithy.APIError
-		if errors.As(err, &apiError) {
-			switch apiError.(type) {
-			case *types.NoSuchEntityException:
-				err = nil
-			default:
-				return nil, logAndTraceError(span, "failed getting user", err, codes.Internal, "user", userName)
-			}
+		return nil, logAndTraceError(ctx, span, "failed building username", err, codes.Internal, "bucket", bucketName)
+	}
+
+	if !iamAuthentication {
+		userName, err = s.usernameFor(ctx, iamClient, userNamespace, req.Name)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed building username", err, codes.Internal, "bucket", bucketName)
 		}
-	} else {
-		user = result.User
 	}
 
-	// Check if IAM user exists.
-	if user != nil {
-		// Case when user exists.
-		log.Warnf("User %s already exists", userName)
-	} else {
-		// Case when user does not exist - create one.
-		user, err := iamClient.CreateUser(ctx, &iam.CreateUserInput{
-			UserName: &userName,
-		})
+	awsBucketResourceARNs := BuildResourceStrings(bucketName)
+	awsPrincipalString := BuildPrincipalString(userName, userNamespace)
+
+	var roleName, serviceAccountNamespace, serviceAccountName string
+	if iamAuthentication {
+		serviceAccountNamespace, serviceAccountName, err = parseServiceAccount(req.Parameters)
 		if err != nil {
-			return nil, logAndTraceError(span, "failed creating user", err, codes.Internal, "user", userName)
+			return nil, logAndTraceError(ctx, span, "invalid service account", err, codes.InvalidArgument, "bucket", bucketName)
 		}
-		log.Infof("Created ObjectScale IAM user %s with ID %v", userName, user.User.UserId)
+
+		roleName = BuildRoleName(s.namespace, req.Name)
+		awsPrincipalString = BuildRoleARN(roleName, s.namespace)
 	}
 
-	// Check if policy for a specific bucket exists.
-	existingPolicy, err := s.mgmtClient.Buckets().GetPolicy(ctx, bucketName, parameters)
+	// Validate all access parameters before any changes are made, so invalid parameters do not leave
+	// orphaned user behind.
+	sourceCIDRs, err := s.sourceCIDRs(req.Parameters)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed getting bucket policy", err, codes.Internal, "bucket", bucketName)
+		return nil, logAndTraceError(ctx, span, "invalid source CIDRs", err, codes.InvalidArgument, "bucket", bucketName)
 	}
 
-	policyRequest := policy.Document{}
-	if existingPolicy != "" {
-		err = json.NewDecoder(strings.NewReader(existingPolicy)).Decode(&policyRequest)
+	ttl, err := parseTTL(req.Parameters)
+	if err != nil {
+		return nil, logAndTraceError(ctx, span, "invalid ttl", err, codes.InvalidArgument, "bucket", bucketName)
+	}
+
+	accessMode, err := parseAccessMode(req.Parameters)
+	if err != nil {
+		return nil, logAndTraceError(ctx, span, "invalid access mode", err, codes.InvalidArgument, "bucket", bucketName)
+	}
+
+	var templateStatements []policy.StatementEntry
+	if templateName := req.Parameters[PolicyTemplateParam]; templateName != "" {
+		// Permissions are defined by the template, so they cannot be also selected by the access mode.
+		if _, ok := req.Parameters[AccessModeParam]; ok {
+			err = fmt.Errorf("parameters %s and %s are mutually exclusive", PolicyTemplateParam, AccessModeParam)
+			return nil, logAndTraceError(ctx, span, "invalid parameters", err, codes.InvalidArgument, "bucket", bucketName)
+		}
+
+		templateStatements, err = s.renderPolicyTemplate(ctx, templateName, PolicyTemplateData{
+			BucketName:   bucketName,
+			PrincipalARN: awsPrincipalString,
+			Namespace:    s.namespace,
+			AccessName:   req.Name,
+		})
 		if err != nil {
-			return nil, logAndTraceError(span, "error parsing policy", err, codes.Internal, "bucket", bucketName)
+			return nil, logAndTraceError(ctx, span, "invalid policy template", err, codes.InvalidArgument, "template", templateName)
 		}
+
+		restrictSourceIP(templateStatements, sourceCIDRs)
 	}
 
-	// Update policy.
-	awsBucketResourceARNs := BuildResourceStrings(bucketName)
-	awsPrincipalString := BuildPrincipalString(userName, s.namespace)
+	if iamAuthentication {
+		err = s.ensureRole(ctx, iamClient, roleName, bucketName, req.Name, serviceAccountNamespace, serviceAccountName)
+		if errors.Is(err, errNameCollision) {
+			return nil, logAndTraceError(ctx, span, "role name collision", err, codes.AlreadyExists, "role", roleName)
+		}
 
-	policyRequest.Statement = parsePolicyStatement(
-		ctx, policyRequest.Statement, awsBucketResourceARNs, awsPrincipalString,
-	)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed creating role", err, codes.Internal, "role", roleName)
+		}
+	} else {
+		// This flow below will check for user existence; if user does not exist, it will create one. It will only fail
+		// in case of an unknown error, e.g. network issues, to adhere to idempotency requirement.
+		var user *types.User
+		result, err := iamClient.GetUser(ctx, &iam.GetUserInput{
+			UserName: aws.String(userName),
+		})
+		if err != nil {
+			var apiError smithy.APIError
+			if errors.As(err, &apiError) {
+				switch apiError.(type) {
+				case *types.NoSuchEntityException:
+					err = nil
+				default:
+					return nil, logAndTraceError(ctx, span, "failed getting user", err, codes.Internal, "user", userName)
+				}
+			}
+		} else {
+			user = result.User
+		}
 
-	log.Debugf("Policy request details: awsBucketResourceARNs: %v, awsPrincipalString: %v, statement: %v", awsBucketResourceARNs, awsPrincipalString, policyRequest.Statement)
-	if policyRequest.Version == "" {
-		policyRequest.Version = bucketVersion
-	}
+		// Check if IAM user exists.
+		if user != nil {
+			// Case when user exists. It is reused only if it was created for the same access, as truncated
+			// or templated names of different accesses may collide.
+			log.Warnf("User %s already exists", userName)
+
+			err = checkUserAccess(ctx, iamClient, userName, req.Name)
+			if errors.Is(err, errNameCollision) {
+				return nil, logAndTraceError(ctx, span, "username collision", err, codes.AlreadyExists, "user", userName)
+			}
+
+			if err != nil {
+				return nil, logAndTraceError(ctx, span, "failed checking user", err, codes.Internal, "user", userName)
+			}
+		} else {
+			// Case when user does not exist - create one.
+			user, err := iamClient.CreateUser(ctx, &iam.CreateUserInput{
+				UserName: &userName,
+				Path:     aws.String(s.iamPath()),
+				Tags:     s.ownershipTags(bucketName, req.Name),
+			})
+			if err != nil {
+				return nil, logAndTraceError(ctx, span, "failed creating user", err, codes.Internal, "user", userName)
+			}
+			log.Infof("Created ObjectScale IAM user %s with ID %v", userName, user.User.UserId)
+		}
 
-	if policyRequest.ID == "" {
-		policyRequest.ID = "bucket-policy"
+		// Record lifetime of the keys on the user, so the reaper can delete them once expired.
+		if ttl > 0 {
+			_, err = iamClient.TagUser(ctx, &iam.TagUserInput{
+				UserName: &userName,
+				Tags:     []types.Tag{{Key: aws.String(ttlTagKey), Value: aws.String(ttl.String())}},
+			})
+			if err != nil {
+				return nil, logAndTraceError(ctx, span, "failed tagging user", err, codes.Internal, "user", userName)
+			}
+		}
 	}
 
-	// Marshal the struct to JSON to confirm JSON validity.
-	updateBucketPolicyJSON, err := json.Marshal(policyRequest)
-	if err != nil {
-		return nil, logAndTraceError(span, "error marshalling policy", err, codes.Internal, "bucket", bucketName)
+	if s.groupAccessManagement() {
+		// Users are granted access by the membership in the group shared by all accesses to the bucket.
+		err = s.addUserToBucketGroup(ctx, iamClient, bucketName, userName, accessMode)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed adding user to bucket group", err, codes.Internal, "bucket", bucketName)
+		}
+	} else {
+		// Check if policy for a specific bucket exists.
+		existingPolicy, err := s.mgmtClient.Buckets().GetPolicy(ctx, bucketName, parameters)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed getting bucket policy", err, codes.Internal, "bucket", bucketName)
+		}
+
+		policyRequest := policy.Document{}
+		if existingPolicy != "" {
+			err = json.NewDecoder(strings.NewReader(existingPolicy)).Decode(&policyRequest)
+			if err != nil {
+				return nil, logAndTraceError(ctx, span, "error parsing policy", err, codes.Internal, "bucket", bucketName)
+			}
+		}
+
+		// Update policy.
+		if templateStatements != nil {
+			policyRequest.Statement = mergePolicyStatements(
+				ctx, policyRequest.Statement, templateStatements, awsPrincipalString,
+			)
+		} else {
+			policyRequest.Statement = parsePolicyStatement(
+				ctx, policyRequest.Statement, awsBucketResourceARNs, awsPrincipalString, accessModeActions(accessMode), sourceCIDRs,
+			)
+		}
+
+		log.Debugf("Policy request details: awsBucketResourceARNs: %v, awsPrincipalString: %v, statement: %v", awsBucketResourceARNs, awsPrincipalString, policyRequest.Statement)
+		if policyRequest.Version == "" {
+			policyRequest.Version = bucketVersion
+		}
+
+		if policyRequest.ID == "" {
+			policyRequest.ID = "bucket-policy"
+		}
+
+		// Marshal the struct to JSON to confirm JSON validity.
+		updateBucketPolicyJSON, err := json.Marshal(policyRequest)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "error marshalling policy", err, codes.Internal, "bucket", bucketName)
+		}
+
+		err = s.mgmtClient.Buckets().UpdatePolicy(ctx, bucketName, string(updateBucketPolicyJSON), parameters)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "error updating policy", err, codes.Internal, "bucket", bucketName)
+		}
 	}
 
-	err = s.mgmtClient.Buckets().UpdatePolicy(ctx, bucketName, string(updateBucketPolicyJSON), parameters)
-	if err != nil {
-		return nil, logAndTraceError(span, "error updating policy", err, codes.Internal, "bucket", bucketName)
+	if iamAuthentication {
+		log.Infof("Successfully granted access to the bucket %s for role %s", bucketName, roleName)
+
+		return &cosi.DriverGrantBucketAccessResponse{
+			AccountId:   awsPrincipalString,
+			Credentials: assembleRoleCredentials(awsPrincipalString, s.s3Endpoint, bucketName),
+		}, nil
 	}
 
 	accessKey, err := iamClient.CreateAccessKey(ctx, &iam.CreateAccessKeyInput{UserName: &userName})
 	if err != nil {
-		return nil, logAndTraceError(span, "failed creating access key", err, codes.Internal, "user", userName)
+		return nil, logAndTraceError(ctx, span, "failed creating access key", err, codes.Internal, "user", userName)
 	}
 
 	credentials := assembleCredentials(ctx, accessKey, s.s3Endpoint, userName, bucketName)
-	return &cosi.DriverGrantBucketAccessResponse{AccountId: userName, Credentials: credentials}, nil
+	if ttl > 0 {
+		expiration := accessKeyExpiration(accessKey.AccessKey, ttl)
+		credentials["s3"].Secrets[ExpirationSecretKey] = expiration.Format(time.RFC3339)
+	}
+
+	return &cosi.DriverGrantBucketAccessResponse{AccountId: s.accountID(userName, userNamespace), Credentials: credentials}, nil
 }
 
 func BuildResourceStrings(bucketName string) []string {
@@ -13,9 +13,12 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/dell/cosi/pkg/config"
 	"github.com/dell/cosi/pkg/internal/testcontext"
 	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+	"github.com/dell/cosi/pkg/provisioner/policy"
 	"github.com/dell/goobjectscale/pkg/client/api/mocks"
 	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -23,6 +26,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	cosi "sigs.k8s.io/container-object-storage-interface/proto"
 )
 
@@ -48,6 +53,39 @@ func TestServerDriverGrantAccess(t *testing.T) {
 		"GrantBucketAccessErrorUpdatingPolicy":    testDriverGrantBucketAccessErrorUpdatingPolicy,
 		"GrantBucketAccessErrorCreatingAccessKey": testDriverGrantBucketAccessErrorCreatingAccessKey,
 		"GrantBucketAccessErrorCreatingUser":      testDriverGrantBucketAccessErrorCreatingUser,
+		// policy templates
+		"GrantAccessExistingPolicyConditions":    testDriverGrantBucketAccessExistingPolicyConditions,
+		"GrantAccessWithPolicyTemplate":          testDriverGrantBucketAccessWithPolicyTemplate,
+		"GrantAccessInvalidPolicyTemplate":       testDriverGrantBucketAccessInvalidPolicyTemplate,
+		"GrantAccessNotConfiguredPolicyTemplate": testDriverGrantBucketAccessNotConfiguredPolicyTemplate,
+		// source IP restrictions
+		"GrantAccessWithSourceCIDRs":    testDriverGrantBucketAccessWithSourceCIDRs,
+		"GrantAccessInvalidSourceCIDRs": testDriverGrantBucketAccessInvalidSourceCIDRs,
+		// time-limited credentials
+		"GrantAccessWithTTL":             testDriverGrantBucketAccessWithTTL,
+		"GrantAccessInvalidTTL":          testDriverGrantBucketAccessInvalidTTL,
+		"GrantAccessErrorTaggingUserTTL": testDriverGrantBucketAccessErrorTaggingUserTTL,
+		// cross-namespace access
+		"GrantAccessCrossNamespace":              testDriverGrantBucketAccessCrossNamespace,
+		"GrantAccessNotConfiguredCrossNamespace": testDriverGrantBucketAccessNotConfiguredCrossNamespace,
+		// access modes and group access management
+		"GrantAccessReadOnly":                     testDriverGrantBucketAccessReadOnly,
+		"GrantAccessInvalidAccessMode":            testDriverGrantBucketAccessInvalidAccessMode,
+		"GrantAccessPolicyTemplateWithAccessMode": testDriverGrantBucketAccessPolicyTemplateWithAccessMode,
+		"GrantAccessGroup":                        testDriverGrantBucketAccessGroup,
+		"GrantAccessGroupUnsupportedParameter":    testDriverGrantBucketAccessGroupUnsupportedParameter,
+		"GrantAccessGroupErrorAddingUser":         testDriverGrantBucketAccessGroupErrorAddingUser,
+		// username collisions
+		"GrantAccessUserOfDifferentAccess": testDriverGrantBucketAccessUserOfDifferentAccess,
+		"GrantAccessErrorCheckingUser":     testDriverGrantBucketAccessErrorCheckingUser,
+		"GrantAccessUsernameTemplate":      testDriverGrantBucketAccessUsernameTemplate,
+		// authentication types
+		"GrantAccessIAMAuthentication":                  testDriverGrantBucketAccessIAMAuthentication,
+		"GrantAccessIAMAuthenticationNotConfigured":     testDriverGrantBucketAccessIAMAuthenticationNotConfigured,
+		"GrantAccessIAMAuthenticationNoServiceAccount":  testDriverGrantBucketAccessIAMAuthenticationNoServiceAccount,
+		"GrantAccessIAMAuthenticationErrorCreatingRole": testDriverGrantBucketAccessIAMAuthenticationErrorCreatingRole,
+		"GrantAccessIAMAuthenticationInvalidModes":      testDriverGrantBucketAccessIAMAuthenticationInvalidModes,
+		"GrantAccessUnsupportedAuthenticationType":      testDriverGrantBucketAccessUnsupportedAuthenticationType,
 	} {
 		fn := fn
 
@@ -147,6 +185,9 @@ func testDriverGrantBucketAccessUserExists(t *testing.T) {
 	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
 		UserName: aws.String("user"),
 	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{
+		Tags: accessTags(testBucketGrantAccessRequest.Name),
+	}, nil).Once()
 	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
 		AccessKey: &types.AccessKey{
 			AccessKeyId:     aws.String("key"),
@@ -419,3 +460,990 @@ func testDriverGrantBucketAccessErrorCreatingUser(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
+
+func testDriverGrantBucketAccessExistingPolicyConditions(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	// statement of the existing policy with boolean and numeric condition values must be kept unchanged
+	existingStatement := `{"Effect":"Deny","Action":["s3:*"],"Resource":["arn:aws:s3:::test_bucket/*"],"Principal":{"AWS":"*"},` +
+		`"Condition":{"Bool":{"aws:SecureTransport":[false]},"NumericLessThanEquals":{"s3:max-keys":[10]}}}`
+	existingPolicy := `{"Version":"2012-10-17","Id":"bucket-policy","Statement":[` + existingStatement + `]}`
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return(existingPolicy, nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, mock.MatchedBy(func(raw string) bool {
+		doc, err := policy.NewFromJSON(raw)
+
+		return err == nil && len(doc.Statement) == 2 && strings.Contains(raw, existingStatement)
+	}), mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateUser", mock.Anything, mock.Anything).Return(&iam.CreateUserOutput{
+		User: &types.User{
+			UserName: aws.String("user"),
+		},
+	}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  val,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, testBucketGrantAccessRequest)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverGrantBucketAccessWithPolicyTemplate(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	templates, err := newPolicyTemplates([]config.PolicyTemplate{
+		{Name: "read-only", Template: testReadOnlyTemplate},
+	})
+	assert.NoError(t, err)
+
+	principal := BuildPrincipalString("namespace-user-bucket-access-id", testNamespace)
+	existingPolicy := `{"Version":"2012-10-17","Id":"bucket-policy","Statement":[{"Sid":"cosi","Effect":"Allow","Action":["*"],` +
+		`"Resource":["arn:aws:s3:::test_bucket"],"Principal":{"AWS":"` + principal + `"}}]}`
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return(existingPolicy, nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, mock.MatchedBy(func(raw string) bool {
+		doc, err := policy.NewFromJSON(raw)
+		if err != nil || len(doc.Statement) != 1 {
+			return false
+		}
+
+		// previous statement for the same principal must be replaced by the rendered one
+		statement := doc.Statement[0]
+		return statement.Sid == PolicySid &&
+			statement.Principal["AWS"] == principal &&
+			!grantsAction(statement, "s3:PutObject") &&
+			grantsAction(statement, "s3:GetObject")
+	}), mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
+		UserName: aws.String("user"),
+	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient:      mgmtClientMock,
+		namespace:       testNamespace,
+		backendID:       testID,
+		iamClient:       val,
+		policyTemplates: templates,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{PolicyTemplateParam: "read-only"},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverGrantBucketAccessInvalidPolicyTemplate(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	// template granting s3:PutBucketPolicy is refused by newPolicyTemplates, so it is parsed without validation
+	templates := parsePolicyTemplates(t, config.PolicyTemplate{Name: "policy-management", Template: testPolicyManagementTemplate})
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// no IAM calls are expected, as the template is rendered before the user is created
+	iamMock := omocks.NewIAM(t)
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient:      mgmtClientMock,
+		namespace:       testNamespace,
+		backendID:       testID,
+		iamClient:       val,
+		policyTemplates: templates,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{PolicyTemplateParam: "policy-management"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessNotConfiguredPolicyTemplate(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  val,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{PolicyTemplateParam: "unknown"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessWithSourceCIDRs(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, mock.MatchedBy(func(raw string) bool {
+		doc, err := policy.NewFromJSON(raw)
+		if err != nil || len(doc.Statement) != 1 {
+			return false
+		}
+
+		// parameter narrows the connection default
+		expected := policy.Condition{
+			"IpAddress": {"aws:SourceIp": policy.ConditionValues{"10.1.0.0/16", "192.168.1.0/24"}},
+		}
+
+		return doc.Statement[0].Condition.Equal(expected)
+	}), mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateUser", mock.Anything, mock.Anything).Return(&iam.CreateUserOutput{
+		User: &types.User{
+			UserName: aws.String("user"),
+		},
+	}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient:         mgmtClientMock,
+		namespace:          testNamespace,
+		backendID:          testID,
+		iamClient:          val,
+		allowedSourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{AllowedSourceCIDRsParam: "10.1.2.3/16, 192.168.1.0/24"},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverGrantBucketAccessInvalidSourceCIDRs(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// no IAM calls are expected, as the parameters are validated before the user is created
+	iamMock := omocks.NewIAM(t)
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  val,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{AllowedSourceCIDRsParam: "10.0.0.0/33"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessWithTTL(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	created := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateUser", mock.Anything, mock.Anything).Return(&iam.CreateUserOutput{
+		User: &types.User{
+			UserName: aws.String("user"),
+		},
+	}, nil).Once()
+	iamMock.On("TagUser", mock.Anything, &iam.TagUserInput{
+		UserName: aws.String("namespace-user-bucket-access-id"),
+		Tags:     []types.Tag{{Key: aws.String(ttlTagKey), Value: aws.String("24h0m0s")}},
+	}).Return(&iam.TagUserOutput{}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+			CreateDate:      &created,
+		},
+	}, nil).Once()
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  val,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{TTLParam: "24h"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-02T12:00:00Z", res.Credentials["s3"].Secrets[ExpirationSecretKey])
+}
+
+func testDriverGrantBucketAccessInvalidTTL(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// no IAM calls are expected, as the parameters are validated before the user is created
+	iamMock := omocks.NewIAM(t)
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  val,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{TTLParam: "forever"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessErrorTaggingUserTTL(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
+		UserName: aws.String("user"),
+	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("TagUser", mock.Anything, mock.Anything).Return(nil, errors.New("failed to tag user")).Once()
+
+	val := func(context.Context) (IAM, error) {
+		return iamMock, nil
+	}
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  val,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{TTLParam: "1h"},
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessCrossNamespace(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	userName := BuildUsername(testCrossNamespace, testBucketGrantAccessRequest.Name)
+	principal := BuildPrincipalString(userName, testCrossNamespace)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, map[string]string{"namespace": testNamespace}).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, mock.MatchedBy(func(raw string) bool {
+		doc, err := policy.NewFromJSON(raw)
+		return err == nil && len(doc.Statement) == 1 && doc.Statement[0].Principal["AWS"] == principal
+	}), map[string]string{"namespace": testNamespace}).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// no calls are expected to the IAM of the bucket namespace
+	iamMock := omocks.NewIAM(t)
+
+	crossIAMMock := omocks.NewIAM(t)
+	crossIAMMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	crossIAMMock.On("CreateUser", mock.Anything, &iam.CreateUserInput{
+		UserName: aws.String(userName),
+		Path:     aws.String("/cosi/" + testClusterID + "/"),
+		Tags:     (&Server{clusterID: testClusterID, backendID: testID}).ownershipTags(testBucketName, testBucketGrantAccessRequest.Name),
+	}).Return(&iam.CreateUserOutput{
+		User: &types.User{
+			UserName: aws.String(userName),
+		},
+	}, nil).Once()
+	crossIAMMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	server := testCrossNamespaceServer(iamMock, crossIAMMock)
+	server.mgmtClient = mgmtClientMock
+	server.backendID = testID
+	server.clusterID = testClusterID
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{UserNamespaceParam: testCrossNamespace},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, principal, res.AccountId)
+}
+
+func testDriverGrantBucketAccessNotConfiguredCrossNamespace(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	server := testCrossNamespaceServer(omocks.NewIAM(t), omocks.NewIAM(t))
+	server.mgmtClient = mocks.NewClientSet(t)
+	server.backendID = testID
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{UserNamespaceParam: "unknown"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessReadOnly(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, mock.MatchedBy(func(raw string) bool {
+		doc, err := policy.NewFromJSON(raw)
+		return err == nil && len(doc.Statement) == 1 &&
+			grantsAction(doc.Statement[0], "s3:GetObject") &&
+			!grantsAction(doc.Statement[0], "s3:PutObject")
+	}), mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
+		UserName: aws.String("user"),
+	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{AccessModeParam: AccessModeReadOnly},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverGrantBucketAccessInvalidAccessMode(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{AccessModeParam: "WriteOnly"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessPolicyTemplateWithAccessMode(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	templates, err := newPolicyTemplates([]config.PolicyTemplate{
+		{Name: "read-only", Template: testReadOnlyTemplate},
+	})
+	assert.NoError(t, err)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	server := Server{
+		mgmtClient:      mgmtClientMock,
+		namespace:       testNamespace,
+		backendID:       testID,
+		iamClient:       func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+		policyTemplates: templates,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{PolicyTemplateParam: "read-only", AccessModeParam: AccessModeReadOnly},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessGroup(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	groupName := BuildGroupName(testBucketName, AccessModeReadWrite)
+
+	// bucket policy is not modified, when access is managed by IAM groups
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateUser", mock.Anything, mock.Anything).Return(&iam.CreateUserOutput{
+		User: &types.User{
+			UserName: aws.String("user"),
+		},
+	}, nil).Once()
+	iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+	iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(&iam.CreatePolicyOutput{}, nil).Once()
+	iamMock.On("AttachGroupPolicy", mock.Anything, mock.Anything).Return(&iam.AttachGroupPolicyOutput{}, nil).Once()
+	iamMock.On("AddUserToGroup", mock.Anything, &iam.AddUserToGroupInput{
+		GroupName: aws.String(groupName),
+		UserName:  aws.String("namespace-user-bucket-access-id"),
+	}).Return(&iam.AddUserToGroupOutput{}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return iamMock, nil },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, testBucketGrantAccessRequest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "namespace-user-bucket-access-id", res.AccountId)
+}
+
+func testDriverGrantBucketAccessGroupUnsupportedParameter(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	server := Server{
+		mgmtClient:       mocks.NewClientSet(t),
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:   testBucketGrantAccessRequest.BucketId,
+		Name:       testBucketGrantAccessRequest.Name,
+		Parameters: map[string]string{AllowedSourceCIDRsParam: "10.0.0.0/8"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessGroupErrorAddingUser(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
+		UserName: aws.String("user"),
+	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(nil, errors.New("failed to get group")).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return iamMock, nil },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, testBucketGrantAccessRequest)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessIAMAuthentication(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	roleName := BuildRoleName(testNamespace, testBucketGrantAccessRequest.Name)
+	roleARN := BuildRoleARN(roleName, testNamespace)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, mock.MatchedBy(func(raw string) bool {
+		doc, err := policy.NewFromJSON(raw)
+		return err == nil && len(doc.Statement) == 1 && doc.Statement[0].Principal["AWS"] == roleARN
+	}), mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// no user nor access key is created
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetRole", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateRole", mock.Anything, mock.MatchedBy(func(input *iam.CreateRoleInput) bool {
+		return aws.ToString(input.RoleName) == roleName &&
+			strings.Contains(aws.ToString(input.AssumeRolePolicyDocument), "system:serviceaccount:app:writer")
+	})).Return(&iam.CreateRoleOutput{}, nil).Once()
+
+	server := Server{
+		mgmtClient:   mgmtClientMock,
+		namespace:    testNamespace,
+		backendID:    testID,
+		s3Endpoint:   "s3.objectstore.test",
+		iamClient:    func(context.Context) (IAM, error) { return iamMock, nil },
+		oidcProvider: &oidcProvider{issuer: "oidc.example.com"},
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           testBucketGrantAccessRequest.BucketId,
+		Name:               testBucketGrantAccessRequest.Name,
+		AuthenticationType: cosi.AuthenticationType_IAM,
+		Parameters:         map[string]string{ServiceAccountParam: "app/writer"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, roleARN, res.AccountId)
+	assert.Equal(t, map[string]string{
+		RoleARNSecretKey: roleARN,
+		"endpoint":       "s3.objectstore.test",
+		"bucketName":     testBucketName,
+	}, res.Credentials["s3"].Secrets)
+}
+
+func testDriverGrantBucketAccessIAMAuthenticationNotConfigured(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	server := Server{
+		mgmtClient: mocks.NewClientSet(t),
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           testBucketGrantAccessRequest.BucketId,
+		Name:               testBucketGrantAccessRequest.Name,
+		AuthenticationType: cosi.AuthenticationType_IAM,
+		Parameters:         map[string]string{ServiceAccountParam: "app/writer"},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessIAMAuthenticationInvalidModes(t *testing.T) {
+	for scenario, tc := range map[string]struct {
+		accessManagement config.ObjectscaleAccessManagement
+		parameters       map[string]string
+	}{
+		"group access management": {
+			accessManagement: config.ObjectscaleAccessManagementGroup,
+			parameters:       map[string]string{ServiceAccountParam: "app/writer"},
+		},
+		"user namespace": {
+			parameters: map[string]string{ServiceAccountParam: "app/writer", UserNamespaceParam: testCrossNamespace},
+		},
+		"ttl": {
+			parameters: map[string]string{ServiceAccountParam: "app/writer", TTLParam: "1h"},
+		},
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			// the mocks fail on any call, as nothing can be created before the request is rejected
+			crossNamespaceIAMMock := omocks.NewIAM(t)
+			server := Server{
+				mgmtClient:       mocks.NewClientSet(t),
+				namespace:        testNamespace,
+				backendID:        testID,
+				accessManagement: tc.accessManagement,
+				iamClient:        func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+				crossNamespaceIAMClients: map[string]func(context.Context) (IAM, error){
+					testCrossNamespace: func(context.Context) (IAM, error) { return crossNamespaceIAMMock, nil },
+				},
+				oidcProvider: &oidcProvider{issuer: "oidc.example.com"},
+			}
+
+			res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+				BucketId:           testBucketGrantAccessRequest.BucketId,
+				Name:               testBucketGrantAccessRequest.Name,
+				AuthenticationType: cosi.AuthenticationType_IAM,
+				Parameters:         tc.parameters,
+			})
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func testDriverGrantBucketAccessIAMAuthenticationNoServiceAccount(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	server := Server{
+		mgmtClient:   mgmtClientMock,
+		namespace:    testNamespace,
+		backendID:    testID,
+		iamClient:    func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+		oidcProvider: &oidcProvider{issuer: "oidc.example.com"},
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           testBucketGrantAccessRequest.BucketId,
+		Name:               testBucketGrantAccessRequest.Name,
+		AuthenticationType: cosi.AuthenticationType_IAM,
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessIAMAuthenticationErrorCreatingRole(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetRole", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateRole", mock.Anything, mock.Anything).Return(nil, errors.New("failed to create role")).Once()
+
+	server := Server{
+		mgmtClient:   mgmtClientMock,
+		namespace:    testNamespace,
+		backendID:    testID,
+		iamClient:    func(context.Context) (IAM, error) { return iamMock, nil },
+		oidcProvider: &oidcProvider{issuer: "oidc.example.com"},
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           testBucketGrantAccessRequest.BucketId,
+		Name:               testBucketGrantAccessRequest.Name,
+		AuthenticationType: cosi.AuthenticationType_IAM,
+		Parameters:         map[string]string{ServiceAccountParam: "app/writer"},
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessUnsupportedAuthenticationType(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	server := Server{
+		mgmtClient: mocks.NewClientSet(t),
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return omocks.NewIAM(t), nil },
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           testBucketGrantAccessRequest.BucketId,
+		Name:               testBucketGrantAccessRequest.Name,
+		AuthenticationType: cosi.AuthenticationType(42),
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessUserOfDifferentAccess(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// user must not be reused, so the revoke of one access does not delete credentials of the other
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
+		UserName: aws.String("user"),
+	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{
+		Tags: accessTags("other-bucket-access-id"),
+	}, nil).Once()
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, testBucketGrantAccessRequest)
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessErrorCheckingUser(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{User: &types.User{
+		UserName: aws.String("user"),
+	}}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(nil, errors.New("failed to list user tags")).Once()
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, testBucketGrantAccessRequest)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Nil(t, res)
+}
+
+func testDriverGrantBucketAccessUsernameTemplate(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	usernameTemplate, err := newUsernameTemplate("cosi-{{ .AccessName }}")
+	assert.NoError(t, err)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, &iam.GetUserInput{UserName: aws.String("cosi-bucket-access-id")}).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("CreateUser", mock.Anything, &iam.CreateUserInput{
+		UserName: aws.String("cosi-bucket-access-id"),
+		Path:     aws.String("/cosi/" + testClusterID + "/"),
+		Tags:     (&Server{clusterID: testClusterID, backendID: testID}).ownershipTags(testBucketName, testBucketGrantAccessRequest.Name),
+	}).Return(&iam.CreateUserOutput{User: &types.User{}}, nil).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{
+		AccessKey: &types.AccessKey{
+			AccessKeyId:     aws.String("key"),
+			SecretAccessKey: aws.String("secret"),
+		},
+	}, nil).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return iamMock, nil },
+		usernameTemplate: usernameTemplate,
+		clusterID:        testClusterID,
+	}
+
+	res, err := server.DriverGrantBucketAccess(ctx, testBucketGrantAccessRequest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cosi-bucket-access-id", res.AccountId)
+}
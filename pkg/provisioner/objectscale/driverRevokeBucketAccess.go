@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"strings"
 
+	logger "github.com/dell/cosi/pkg/logger"
 	"github.com/dell/cosi/pkg/provisioner/policy"
 	"github.com/dell/csmlog"
 	"github.com/dell/goobjectscale/pkg/client/model"
@@ -23,7 +24,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	smithy "github.com/aws/smithy-go"
 	"go.opentelemetry.io/otel"
-	"google.golang.org/grpc/status"
 	cosi "sigs.k8s.io/container-object-storage-interface/proto"
 
 	otelCodes "go.opentelemetry.io/otel/codes"
@@ -48,15 +48,33 @@ func (s *Server) DriverRevokeBucketAccess(ctx context.Context,
 	ctx, span := otel.Tracer(CreateBucketTraceName).Start(ctx, "DriverRevokeBucketAccess")
 	defer span.End()
 
+	ctx, cancel := withTimeout(ctx, s.timeouts.revokeBucketAccess)
+	defer cancel()
+
 	bucketName, err := GetBucketNameFromID(req.GetBucketId())
 	if err != nil {
-		return nil, logAndTraceError(span, "invalid bucket name", err, codes.InvalidArgument)
+		return nil, logAndTraceError(ctx, span, "invalid bucket name", err, codes.InvalidArgument)
+	}
+
+	// Accesses with IAM authentication are identified by the role, instead of the user.
+	roleName, isRole, err := s.parseRoleARN(req.GetAccountId())
+	if err != nil {
+		return nil, logAndTraceError(ctx, span, "invalid account id", err, codes.InvalidArgument, "role", req.GetAccountId())
+	}
+
+	userName, userNamespace := "", s.namespace
+	if !isRole {
+		// User can be in a namespace other than the namespace of the bucket.
+		userName, userNamespace, err = s.parseAccountID(req.GetAccountId())
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "invalid account id", err, codes.InvalidArgument, "user", req.GetAccountId())
+		}
 	}
 
 	log.Infof("Revoking access to bucket %s for user %s", bucketName, req.GetAccountId())
-	iamClient, err := s.iamClient(ctx)
+	iamClient, err := s.iamClientFor(ctx, userNamespace)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed to create IAM client", err, codes.Internal)
+		return nil, logAndTraceError(ctx, span, "failed to create IAM client", err, codes.Internal)
 	}
 
 	parameters := map[string]string{}
@@ -65,27 +83,67 @@ func (s *Server) DriverRevokeBucketAccess(ctx context.Context,
 	// Check if bucket for revoking access exists.
 	bucketExists, err := checkBucketExistence(ctx, s, bucketName, parameters)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
+		return nil, logAndTraceError(ctx, span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
+	}
+
+	if isRole {
+		// Roles created by other clusters or for other buckets must never be deleted.
+		roleExists, err := s.checkRoleOwnership(ctx, iamClient, roleName, bucketName)
+		if errors.Is(err, errNotOwned) {
+			return nil, logAndTraceError(ctx, span, "role not owned by the driver", err, codes.FailedPrecondition, "role", req.GetAccountId())
+		}
+
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed checking role", err, codes.Internal, "role", req.GetAccountId())
+		}
+
+		if bucketExists {
+			err := removeBucketPolicy(ctx, s, bucketName, req.GetAccountId(), parameters)
+			if err != nil {
+				return nil, logAndTraceError(ctx, span, "failed removing bucket policy", err, codes.Internal, "bucket", bucketName)
+			}
+		}
+
+		if roleExists {
+			if err := deleteRole(ctx, iamClient, roleName); err != nil {
+				return nil, logAndTraceError(ctx, span, "failed deleting role", err, codes.Internal, "role", req.GetAccountId())
+			}
+		}
+
+		log.Infof("Revoked access to bucket %s for role %s", bucketName, req.GetAccountId())
+		return &cosi.DriverRevokeBucketAccessResponse{}, nil
 	}
 
 	// Check user existence.
-	userExists, err := checkUserExistence(ctx, iamClient, req.GetAccountId())
+	userExists, err := checkUserExistence(ctx, iamClient, userName)
 	if err != nil {
-		return nil, logAndTraceError(span, "failed checking if user exists", err, codes.Internal, "user", req.GetAccountId())
+		return nil, logAndTraceError(ctx, span, "failed checking if user exists", err, codes.Internal, "user", req.GetAccountId())
 	}
 
-	principalUsername := BuildPrincipalString(req.AccountId, s.namespace)
+	// Users created by other clusters or for other buckets must never be deleted.
+	if userExists {
+		err = s.checkUserOwnership(ctx, iamClient, userName, bucketName)
+		if errors.Is(err, errNotOwned) {
+			return nil, logAndTraceError(ctx, span, "user not owned by the driver", err, codes.FailedPrecondition, "user", req.GetAccountId())
+		}
+
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed checking user", err, codes.Internal, "user", req.GetAccountId())
+		}
+	}
+
+	principalUsername := BuildPrincipalString(userName, userNamespace)
 
 	if bucketExists {
 		err := removeBucketPolicy(ctx, s, bucketName, principalUsername, parameters)
 		if err != nil {
-			return nil, logAndTraceError(span, "failed removing bucket policy", err, codes.Internal, "bucket", bucketName)
+			return nil, logAndTraceError(ctx, span, "failed removing bucket policy", err, codes.Internal, "bucket", bucketName)
 		}
 	}
 
 	if userExists {
-		if err := deleteUser(ctx, iamClient, req.AccountId); err != nil {
-			return nil, logAndTraceError(span, "failed deleting user", err, codes.Internal, "user", req.GetAccountId())
+		if err := deleteUser(ctx, iamClient, userName); err != nil {
+			return nil, logAndTraceError(ctx, span, "failed deleting user", err, codes.Internal, "user", req.GetAccountId())
 		}
 	}
 
@@ -209,6 +267,11 @@ func checkBucketExistence(ctx context.Context, s *Server, bucketName string, par
 }
 
 func deleteUser(ctx context.Context, iamClient IAM, accountID string) error {
+	// Remove user from groups, including groups created for buckets.
+	if err := removeUserFromGroups(ctx, iamClient, accountID); err != nil {
+		return err
+	}
+
 	// Get access keys list.
 	accessKeyList, err := iamClient.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: &accountID})
 	if err != nil {
@@ -249,7 +312,9 @@ func kvToFields(keysAndValues ...any) csmlog.Fields {
 }
 
 // logAndTraceError is a helper function that logs an error with specified fields and records it in a span.
-func logAndTraceError(span trace.Span, errMsg string, err error, code codes.Code, keysAndValues ...any) error {
+// The correlation ID of the request is added to the fields, if the context carries one.
+// Internal code is refined using the error, see classifyError, and the ObjectScale error code is attached to the status.
+func logAndTraceError(ctx context.Context, span trace.Span, errMsg string, err error, code codes.Code, keysAndValues ...any) error {
 	fields := kvToFields(keysAndValues...)
 
 	// Add the error as a structured field
@@ -257,9 +322,16 @@ func logAndTraceError(span trace.Span, errMsg string, err error, code codes.Code
 		fields["error"] = err
 	}
 
+	if requestID := logger.RequestID(ctx); requestID != "" {
+		fields[logger.RequestIDField] = requestID
+	}
+
+	code, backendErr := classifyError(ctx, err, code)
+	fields["code"] = code.String()
+
 	log.WithFields(fields).Error(errMsg)
 	span.RecordError(err)
 	span.SetStatus(otelCodes.Error, errMsg)
 
-	return status.Error(code, errMsg)
+	return statusError(code, errMsg, backendErr)
 }
@@ -19,14 +19,17 @@ import (
 	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
 	"github.com/dell/cosi/pkg/provisioner/policy"
 
-	"github.com/dell/cosi/pkg/internal/testcontext"
-	"github.com/dell/goobjectscale/pkg/client/api/mocks"
-	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	cosi "sigs.k8s.io/container-object-storage-interface/proto"
 )
 
@@ -42,6 +45,12 @@ func TestServerDriverRevokeAccess(t *testing.T) {
 		// happy path
 		"RevokeAccess":                     testDriverRevokeBucketAccess,
 		"RevokeAccessWithMultiplePolicies": testDriverRevokeBucketAccessWithMultiplePolicies,
+		"RevokeAccessCrossNamespace":       testDriverRevokeBucketAccessCrossNamespace,
+		"RevokeAccessGroupMember":          testDriverRevokeBucketAccessGroupMember,
+		"RevokeAccessRole":                 testDriverRevokeBucketAccessRole,
+		// testing errors
+		"RevokeAccessInvalidAccountID":   testDriverRevokeBucketAccessInvalidAccountID,
+		"RevokeAccessUserOfOtherCluster": testDriverRevokeBucketAccessUserOfOtherCluster,
 	} {
 		fn := fn
 
@@ -85,6 +94,8 @@ func testDriverRevokeBucketAccess(t *testing.T) {
 
 	iamMock := omocks.NewIAM(t)
 	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, errors.New("error")).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("ListGroupsForUser", mock.Anything, mock.Anything).Return(&iam.ListGroupsForUserOutput{}, nil).Once()
 	iamMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(&iam.ListAccessKeysOutput{
 		AccessKeyMetadata: []types.AccessKeyMetadata{
 			{
@@ -152,6 +163,8 @@ func testDriverRevokeBucketAccessWithMultiplePolicies(t *testing.T) {
 
 	iamMock := omocks.NewIAM(t)
 	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, errors.New("error")).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("ListGroupsForUser", mock.Anything, mock.Anything).Return(&iam.ListGroupsForUserOutput{}, nil).Once()
 	iamMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(&iam.ListAccessKeysOutput{
 		AccessKeyMetadata: []types.AccessKeyMetadata{
 			{
@@ -179,6 +192,118 @@ func testDriverRevokeBucketAccessWithMultiplePolicies(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+func testDriverRevokeBucketAccessCrossNamespace(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	userName := BuildUsername(testCrossNamespace, "access")
+	principal := BuildPrincipalString(userName, testCrossNamespace)
+
+	bucketPolicy := policy.Document{
+		Version: "2012-10-17",
+		Statement: []policy.StatementEntry{
+			{
+				Effect:    "Allow",
+				Action:    []string{"*"},
+				Resource:  BuildResourceStrings(testBucketName),
+				Principal: map[string]string{"AWS": principal},
+				Sid:       PolicySid,
+			},
+		},
+	}
+	bucketPolicyJSON, err := json.Marshal(bucketPolicy)
+	assert.Nil(t, err)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, map[string]string{"namespace": testNamespace}).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return(string(bucketPolicyJSON), nil).Once()
+	bucketsMock.On("DeletePolicy", mock.Anything, testBucketName, map[string]string{"namespace": testNamespace}).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// no calls are expected to the IAM of the bucket namespace
+	iamMock := omocks.NewIAM(t)
+
+	crossIAMMock := omocks.NewIAM(t)
+	crossIAMMock.On("GetUser", mock.Anything, &iam.GetUserInput{UserName: aws.String(userName)}).Return(&iam.GetUserOutput{}, nil).Once()
+	crossIAMMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	crossIAMMock.On("ListGroupsForUser", mock.Anything, mock.Anything).Return(&iam.ListGroupsForUserOutput{}, nil).Once()
+	crossIAMMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(&iam.ListAccessKeysOutput{}, nil).Once()
+	crossIAMMock.On("DeleteUser", mock.Anything, &iam.DeleteUserInput{UserName: aws.String(userName)}).Return(nil, nil).Once()
+
+	server := testCrossNamespaceServer(iamMock, crossIAMMock)
+	server.mgmtClient = mgmtClientMock
+	server.backendID = testID
+
+	res, err := server.DriverRevokeBucketAccess(ctx, &cosi.DriverRevokeBucketAccessRequest{
+		BucketId:  testBucketRevokeAccessRequest.BucketId,
+		AccountId: principal,
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverRevokeBucketAccessGroupMember(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	userName := testBucketRevokeAccessRequest.AccountId
+	groupName := BuildGroupName(testBucketName, AccessModeReadOnly)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// user must be removed from the group before it is deleted
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+	iamMock.On("ListGroupsForUser", mock.Anything, &iam.ListGroupsForUserInput{UserName: aws.String(userName)}).Return(&iam.ListGroupsForUserOutput{
+		Groups: []types.Group{{GroupName: aws.String(groupName)}},
+	}, nil).Once()
+	iamMock.On("RemoveUserFromGroup", mock.Anything, &iam.RemoveUserFromGroupInput{
+		GroupName: aws.String(groupName),
+		UserName:  aws.String(userName),
+	}).Return(&iam.RemoveUserFromGroupOutput{}, nil).Once()
+	iamMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(&iam.ListAccessKeysOutput{}, nil).Once()
+	iamMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	server := Server{
+		mgmtClient:       mgmtClientMock,
+		namespace:        testNamespace,
+		backendID:        testID,
+		iamClient:        func(context.Context) (IAM, error) { return iamMock, nil },
+		accessManagement: config.ObjectscaleAccessManagementGroup,
+	}
+
+	res, err := server.DriverRevokeBucketAccess(ctx, testBucketRevokeAccessRequest)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverRevokeBucketAccessInvalidAccountID(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	server := testCrossNamespaceServer(omocks.NewIAM(t), omocks.NewIAM(t))
+	server.mgmtClient = mocks.NewClientSet(t)
+	server.backendID = testID
+
+	res, err := server.DriverRevokeBucketAccess(ctx, &cosi.DriverRevokeBucketAccessRequest{
+		BucketId:  testBucketRevokeAccessRequest.BucketId,
+		AccountId: BuildPrincipalString("user", "unknown"),
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, res)
+}
+
 func TestKvToFields(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -221,3 +346,87 @@ func TestKvToFields(t *testing.T) {
 		})
 	}
 }
+
+func testDriverRevokeBucketAccessRole(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	roleARN := BuildRoleARN("role", testNamespace)
+
+	bucketPolicy := policy.Document{
+		Version: "2012-10-17",
+		Statement: []policy.StatementEntry{
+			{
+				Effect:    "Allow",
+				Action:    []string{"*"},
+				Resource:  BuildResourceStrings(testBucketName),
+				Principal: map[string]string{"AWS": roleARN},
+				Sid:       PolicySid,
+			},
+		},
+	}
+	bucketPolicyJSON, err := json.Marshal(bucketPolicy)
+	assert.Nil(t, err)
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return(string(bucketPolicyJSON), nil).Once()
+	bucketsMock.On("DeletePolicy", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// role is deleted instead of the user
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String("role")}).Return(&iam.GetRoleOutput{
+		Role: &types.Role{Tags: (&Server{clusterID: testClusterID}).ownershipTags(testBucketName, "access")},
+	}, nil).Once()
+	iamMock.On("DeleteRole", mock.Anything, &iam.DeleteRoleInput{RoleName: aws.String("role")}).Return(&iam.DeleteRoleOutput{}, nil).Once()
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+		clusterID:  testClusterID,
+	}
+
+	res, err := server.DriverRevokeBucketAccess(ctx, &cosi.DriverRevokeBucketAccessRequest{
+		BucketId:  testBucketRevokeAccessRequest.BucketId,
+		AccountId: roleARN,
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
+func testDriverRevokeBucketAccessUserOfOtherCluster(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&model.Bucket{}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	// neither bucket policy nor user are modified
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{}, nil).Once()
+	iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{
+		Tags: (&Server{clusterID: "other"}).ownershipTags(testBucketName, "access"),
+	}, nil).Once()
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+		clusterID:  testClusterID,
+	}
+
+	res, err := server.DriverRevokeBucketAccess(ctx, testBucketRevokeAccessRequest)
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Nil(t, res)
+}
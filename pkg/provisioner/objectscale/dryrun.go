@@ -0,0 +1,646 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/goobjectscale/pkg/client/api"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/google/uuid"
+
+	"github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+// dryRunSecretAccessKey is the secret of the access keys synthesized in dry run.
+const dryRunSecretAccessKey = "dry-run"
+
+var _ virtualdriver.DryRunner = (*Server)(nil)
+
+// EnableDryRun makes the driver log the mutating calls to ObjectScale with their payload, instead of sending them,
+// and synthesize their responses. Reads are still sent to ObjectScale, overlaid with the buckets, bucket policies,
+// users, groups and roles changed in dry run, so the following operations see the results of the previous ones.
+func (s *Server) EnableDryRun() {
+	s.mgmtClient = &dryRunClientSet{ClientSet: s.mgmtClient, buckets: map[string]*dryRunBucket{}}
+	s.iamClient = dryRunIAMClient(s.iamClient)
+
+	for namespace, iamClient := range s.crossNamespaceIAMClients {
+		s.crossNamespaceIAMClients[namespace] = dryRunIAMClient(iamClient)
+	}
+
+	log.WithFields(csmlog.Fields{"id": s.backendID}).Warn("Dry run enabled, mutating calls to ObjectScale are not sent")
+}
+
+// logIntercepted logs the mutating call to ObjectScale, which is not sent in dry run.
+func logIntercepted(call string, payload any) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		b = fmt.Appendf(nil, "%+v", payload)
+	}
+
+	log.WithFields(csmlog.Fields{"call": call, "payload": string(b)}).Info("Dry run: call to ObjectScale not sent")
+}
+
+// dryRunBucket is the bucket changed in dry run.
+type dryRunBucket struct {
+	// bucket created in dry run, nil if the bucket exists on ObjectScale
+	bucket *model.Bucket
+	// deleted in dry run
+	deleted bool
+	// policy set in dry run, nil if not changed
+	policy *string
+}
+
+// dryRunClientSet intercepts the mutating calls to the ObjectScale management API.
+type dryRunClientSet struct {
+	api.ClientSet
+
+	// mu guards buckets, which are indexed by name.
+	mu      sync.Mutex
+	buckets map[string]*dryRunBucket
+}
+
+var _ api.ClientSet = (*dryRunClientSet)(nil)
+
+func (c *dryRunClientSet) Buckets() api.BucketServiceInterface {
+	return &dryRunBuckets{BucketServiceInterface: c.ClientSet.Buckets(), client: c}
+}
+
+// bucket returns the state of the bucket changed in dry run, nil if the bucket was not changed.
+func (c *dryRunClientSet) bucket(name string) (dryRunBucket, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[name]
+	if !ok {
+		return dryRunBucket{}, false
+	}
+
+	return *b, true
+}
+
+// change applies the change to the state of the bucket.
+func (c *dryRunClientSet) change(name string, change func(b *dryRunBucket)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[name]
+	if !ok {
+		b = &dryRunBucket{}
+		c.buckets[name] = b
+	}
+
+	change(b)
+}
+
+type dryRunBuckets struct {
+	api.BucketServiceInterface
+	client *dryRunClientSet
+}
+
+func (b *dryRunBuckets) Create(_ context.Context, createParam *model.ObjectBucketParam) (*model.Bucket, error) {
+	logIntercepted("Buckets().Create", createParam)
+
+	bucket := model.Bucket{
+		Name:            createParam.Name,
+		Namespace:       createParam.Namespace,
+		Vpool:           createParam.Vpool,
+		FsAccessEnabled: createParam.FsAccessEnabled,
+		IsStaleAllowed:  createParam.IsStaleAllowed,
+		Created:         time.Now(),
+	}
+
+	b.client.change(createParam.Name, func(b *dryRunBucket) {
+		*b = dryRunBucket{bucket: &bucket}
+	})
+
+	return &bucket, nil
+}
+
+func (b *dryRunBuckets) Get(ctx context.Context, bucketName string, param map[string]string) (*model.Bucket, error) {
+	state, ok := b.client.bucket(bucketName)
+
+	switch {
+	case !ok:
+		return b.BucketServiceInterface.Get(ctx, bucketName, param)
+	case state.deleted:
+		return nil, model.ErrParameterNotFound
+	case state.bucket != nil:
+		bucket := *state.bucket
+		return &bucket, nil
+	default:
+		return b.BucketServiceInterface.Get(ctx, bucketName, param)
+	}
+}
+
+func (b *dryRunBuckets) Delete(_ context.Context, bucketName string, param map[string]string) error {
+	logIntercepted("Buckets().Delete", map[string]any{"bucketName": bucketName, "param": param})
+
+	b.client.change(bucketName, func(b *dryRunBucket) {
+		*b = dryRunBucket{deleted: true}
+	})
+
+	return nil
+}
+
+func (b *dryRunBuckets) UpdatePolicy(_ context.Context, bucketName string, policy string, param map[string]string) error {
+	logIntercepted("Buckets().UpdatePolicy", map[string]any{"bucketName": bucketName, "policy": policy, "param": param})
+
+	b.client.change(bucketName, func(b *dryRunBucket) {
+		b.policy = &policy
+	})
+
+	return nil
+}
+
+func (b *dryRunBuckets) GetPolicy(ctx context.Context, bucketName string, param map[string]string) (string, error) {
+	state, ok := b.client.bucket(bucketName)
+
+	switch {
+	case !ok:
+		return b.BucketServiceInterface.GetPolicy(ctx, bucketName, param)
+	case state.deleted:
+		return "", model.ErrParameterNotFound
+	case state.policy != nil:
+		return *state.policy, nil
+	case state.bucket != nil:
+		// bucket created in dry run has no policy
+		return "", nil
+	default:
+		return b.BucketServiceInterface.GetPolicy(ctx, bucketName, param)
+	}
+}
+
+func (b *dryRunBuckets) DeletePolicy(_ context.Context, bucketName string, param map[string]string) error {
+	logIntercepted("Buckets().DeletePolicy", map[string]any{"bucketName": bucketName, "param": param})
+
+	b.client.change(bucketName, func(b *dryRunBucket) {
+		b.policy = aws.String("")
+	})
+
+	return nil
+}
+
+// dryRunIAMClient wraps the IAM client returned by the function, so the mutating calls are intercepted.
+// The IAM client is cached by its factory, so the wrapper is created once, and the users changed in dry run
+// are seen by all operations.
+func dryRunIAMClient(iamClient func(context.Context) (IAM, error)) func(context.Context) (IAM, error) {
+	var (
+		mu     sync.Mutex
+		dryRun *dryRunIAM
+	)
+
+	return func(ctx context.Context) (IAM, error) {
+		client, err := iamClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if dryRun == nil {
+			dryRun = &dryRunIAM{
+				IAM:    client,
+				users:  map[string]*dryRunUser{},
+				groups: map[string]*types.Group{},
+				roles:  map[string]*types.Role{},
+			}
+		}
+
+		return dryRun, nil
+	}
+}
+
+// dryRunUser is the IAM user changed in dry run.
+type dryRunUser struct {
+	// user created in dry run, nil if the user exists on ObjectScale
+	user *types.User
+	// deleted in dry run
+	deleted    bool
+	tags       []types.Tag
+	accessKeys []types.AccessKeyMetadata
+	groups     []types.Group
+}
+
+// dryRunIAM intercepts the mutating calls to the ObjectScale IAM API.
+type dryRunIAM struct {
+	IAM
+
+	// mu guards users, groups and roles, which are indexed by name.
+	mu    sync.Mutex
+	users map[string]*dryRunUser
+	// groups and roles created in dry run, nil if deleted in dry run
+	groups map[string]*types.Group
+	roles  map[string]*types.Role
+}
+
+var _ IAM = (*dryRunIAM)(nil)
+
+// user returns the copy of the user changed in dry run, nil if the user was not changed.
+func (r *dryRunIAM) user(userName *string) *dryRunUser {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[aws.ToString(userName)]
+	if !ok {
+		return nil
+	}
+
+	return &dryRunUser{
+		user:       u.user,
+		deleted:    u.deleted,
+		tags:       slices.Clone(u.tags),
+		accessKeys: slices.Clone(u.accessKeys),
+		groups:     slices.Clone(u.groups),
+	}
+}
+
+// group returns the group changed in dry run, nil if it was deleted, and false if it was not changed.
+func (r *dryRunIAM) group(groupName *string) (*types.Group, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	g, ok := r.groups[aws.ToString(groupName)]
+
+	return g, ok
+}
+
+// members returns the users created in dry run, which are members of the group. Members of the groups are tracked
+// only for the users created in dry run, see ListGroupsForUser.
+func (r *dryRunIAM) members(groupName *string) []types.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var users []types.User
+
+	for _, u := range r.users {
+		member := slices.ContainsFunc(u.groups, func(g types.Group) bool {
+			return aws.ToString(g.GroupName) == aws.ToString(groupName)
+		})
+		if u.user != nil && member {
+			users = append(users, *u.user)
+		}
+	}
+
+	return users
+}
+
+// role returns the role changed in dry run, nil if it was deleted, and false if it was not changed.
+func (r *dryRunIAM) role(roleName *string) (*types.Role, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[aws.ToString(roleName)]
+
+	return role, ok
+}
+
+// change applies the change to the user created in dry run. Users existing on ObjectScale are not changed,
+// as their state is not known.
+func (r *dryRunIAM) change(userName *string, change func(u *dryRunUser)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u, ok := r.users[aws.ToString(userName)]; ok && u.user != nil {
+		change(u)
+	}
+}
+
+// noSuchUser is the error returned for the user deleted in dry run.
+func noSuchUser(userName *string) error {
+	return noSuchEntity("user", userName)
+}
+
+// noSuchEntity is the error returned for the IAM entity of the kind deleted in dry run.
+func noSuchEntity(kind string, name *string) error {
+	return &types.NoSuchEntityException{Message: aws.String(fmt.Sprintf("%s %s does not exist", kind, aws.ToString(name)))}
+}
+
+func (r *dryRunIAM) AddUserToGroup(_ context.Context, params *iam.AddUserToGroupInput, _ ...func(*iam.Options)) (*iam.AddUserToGroupOutput, error) {
+	logIntercepted("IAM.AddUserToGroup", params)
+
+	r.change(params.UserName, func(u *dryRunUser) {
+		member := slices.ContainsFunc(u.groups, func(g types.Group) bool {
+			return aws.ToString(g.GroupName) == aws.ToString(params.GroupName)
+		})
+		if !member {
+			u.groups = append(u.groups, types.Group{GroupName: params.GroupName})
+		}
+	})
+
+	return &iam.AddUserToGroupOutput{}, nil
+}
+
+func (r *dryRunIAM) AttachGroupPolicy(_ context.Context, params *iam.AttachGroupPolicyInput, _ ...func(*iam.Options)) (*iam.AttachGroupPolicyOutput, error) {
+	logIntercepted("IAM.AttachGroupPolicy", params)
+	return &iam.AttachGroupPolicyOutput{}, nil
+}
+
+func (r *dryRunIAM) CreateAccessKey(_ context.Context, params *iam.CreateAccessKeyInput, _ ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error) {
+	logIntercepted("IAM.CreateAccessKey", params)
+
+	now := time.Now()
+	accessKey := &types.AccessKey{
+		AccessKeyId:     aws.String("DRYRUN" + uuid.NewString()),
+		SecretAccessKey: aws.String(dryRunSecretAccessKey),
+		Status:          types.StatusTypeActive,
+		UserName:        params.UserName,
+		CreateDate:      &now,
+	}
+
+	r.change(params.UserName, func(u *dryRunUser) {
+		u.accessKeys = append(u.accessKeys, types.AccessKeyMetadata{
+			AccessKeyId: accessKey.AccessKeyId,
+			Status:      accessKey.Status,
+			UserName:    accessKey.UserName,
+			CreateDate:  accessKey.CreateDate,
+		})
+	})
+
+	return &iam.CreateAccessKeyOutput{AccessKey: accessKey}, nil
+}
+
+func (r *dryRunIAM) CreateGroup(_ context.Context, params *iam.CreateGroupInput, _ ...func(*iam.Options)) (*iam.CreateGroupOutput, error) {
+	logIntercepted("IAM.CreateGroup", params)
+
+	now := time.Now()
+	group := &types.Group{
+		GroupName:  params.GroupName,
+		GroupId:    aws.String("DRYRUN" + uuid.NewString()),
+		Path:       params.Path,
+		CreateDate: &now,
+	}
+
+	r.mu.Lock()
+	r.groups[aws.ToString(params.GroupName)] = group
+	r.mu.Unlock()
+
+	return &iam.CreateGroupOutput{Group: group}, nil
+}
+
+func (r *dryRunIAM) CreatePolicy(_ context.Context, params *iam.CreatePolicyInput, _ ...func(*iam.Options)) (*iam.CreatePolicyOutput, error) {
+	logIntercepted("IAM.CreatePolicy", params)
+
+	now := time.Now()
+
+	return &iam.CreatePolicyOutput{Policy: &types.Policy{PolicyName: params.PolicyName, Path: params.Path, CreateDate: &now}}, nil
+}
+
+func (r *dryRunIAM) CreatePolicyVersion(_ context.Context, params *iam.CreatePolicyVersionInput, _ ...func(*iam.Options)) (*iam.CreatePolicyVersionOutput, error) {
+	logIntercepted("IAM.CreatePolicyVersion", params)
+
+	now := time.Now()
+
+	return &iam.CreatePolicyVersionOutput{PolicyVersion: &types.PolicyVersion{
+		VersionId:        aws.String("DRYRUN" + uuid.NewString()),
+		Document:         params.PolicyDocument,
+		IsDefaultVersion: params.SetAsDefault,
+		CreateDate:       &now,
+	}}, nil
+}
+
+func (r *dryRunIAM) CreateRole(_ context.Context, params *iam.CreateRoleInput, _ ...func(*iam.Options)) (*iam.CreateRoleOutput, error) {
+	logIntercepted("IAM.CreateRole", params)
+
+	now := time.Now()
+	role := &types.Role{
+		RoleName:                 params.RoleName,
+		RoleId:                   aws.String("DRYRUN" + uuid.NewString()),
+		Path:                     params.Path,
+		AssumeRolePolicyDocument: params.AssumeRolePolicyDocument,
+		Tags:                     slices.Clone(params.Tags),
+		CreateDate:               &now,
+	}
+
+	r.mu.Lock()
+	r.roles[aws.ToString(params.RoleName)] = role
+	r.mu.Unlock()
+
+	return &iam.CreateRoleOutput{Role: role}, nil
+}
+
+func (r *dryRunIAM) CreateUser(_ context.Context, params *iam.CreateUserInput, _ ...func(*iam.Options)) (*iam.CreateUserOutput, error) {
+	logIntercepted("IAM.CreateUser", params)
+
+	now := time.Now()
+	user := &types.User{
+		UserName:   params.UserName,
+		UserId:     aws.String("DRYRUN" + uuid.NewString()),
+		Path:       params.Path,
+		Tags:       params.Tags,
+		CreateDate: &now,
+	}
+
+	r.mu.Lock()
+	r.users[aws.ToString(params.UserName)] = &dryRunUser{user: user, tags: slices.Clone(params.Tags)}
+	r.mu.Unlock()
+
+	return &iam.CreateUserOutput{User: user}, nil
+}
+
+func (r *dryRunIAM) DeleteAccessKey(_ context.Context, params *iam.DeleteAccessKeyInput, _ ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error) {
+	logIntercepted("IAM.DeleteAccessKey", params)
+
+	r.change(params.UserName, func(u *dryRunUser) {
+		u.accessKeys = slices.DeleteFunc(u.accessKeys, func(key types.AccessKeyMetadata) bool {
+			return aws.ToString(key.AccessKeyId) == aws.ToString(params.AccessKeyId)
+		})
+	})
+
+	return &iam.DeleteAccessKeyOutput{}, nil
+}
+
+func (r *dryRunIAM) DeleteGroup(_ context.Context, params *iam.DeleteGroupInput, _ ...func(*iam.Options)) (*iam.DeleteGroupOutput, error) {
+	logIntercepted("IAM.DeleteGroup", params)
+
+	r.mu.Lock()
+	r.groups[aws.ToString(params.GroupName)] = nil
+	r.mu.Unlock()
+
+	return &iam.DeleteGroupOutput{}, nil
+}
+
+func (r *dryRunIAM) DeletePolicy(_ context.Context, params *iam.DeletePolicyInput, _ ...func(*iam.Options)) (*iam.DeletePolicyOutput, error) {
+	logIntercepted("IAM.DeletePolicy", params)
+	return &iam.DeletePolicyOutput{}, nil
+}
+
+func (r *dryRunIAM) DeletePolicyVersion(_ context.Context, params *iam.DeletePolicyVersionInput, _ ...func(*iam.Options)) (*iam.DeletePolicyVersionOutput, error) {
+	logIntercepted("IAM.DeletePolicyVersion", params)
+	return &iam.DeletePolicyVersionOutput{}, nil
+}
+
+func (r *dryRunIAM) DeleteRole(_ context.Context, params *iam.DeleteRoleInput, _ ...func(*iam.Options)) (*iam.DeleteRoleOutput, error) {
+	logIntercepted("IAM.DeleteRole", params)
+
+	r.mu.Lock()
+	r.roles[aws.ToString(params.RoleName)] = nil
+	r.mu.Unlock()
+
+	return &iam.DeleteRoleOutput{}, nil
+}
+
+func (r *dryRunIAM) DeleteUser(_ context.Context, params *iam.DeleteUserInput, _ ...func(*iam.Options)) (*iam.DeleteUserOutput, error) {
+	logIntercepted("IAM.DeleteUser", params)
+
+	r.mu.Lock()
+	r.users[aws.ToString(params.UserName)] = &dryRunUser{deleted: true}
+	r.mu.Unlock()
+
+	return &iam.DeleteUserOutput{}, nil
+}
+
+func (r *dryRunIAM) DetachGroupPolicy(_ context.Context, params *iam.DetachGroupPolicyInput, _ ...func(*iam.Options)) (*iam.DetachGroupPolicyOutput, error) {
+	logIntercepted("IAM.DetachGroupPolicy", params)
+	return &iam.DetachGroupPolicyOutput{}, nil
+}
+
+func (r *dryRunIAM) GetGroup(ctx context.Context, params *iam.GetGroupInput, optFns ...func(*iam.Options)) (*iam.GetGroupOutput, error) {
+	group, ok := r.group(params.GroupName)
+
+	switch {
+	case !ok:
+		return r.IAM.GetGroup(ctx, params, optFns...)
+	case group == nil:
+		return nil, noSuchEntity("group", params.GroupName)
+	default:
+		return &iam.GetGroupOutput{Group: group, Users: r.members(params.GroupName)}, nil
+	}
+}
+
+func (r *dryRunIAM) GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	role, ok := r.role(params.RoleName)
+
+	switch {
+	case !ok:
+		return r.IAM.GetRole(ctx, params, optFns...)
+	case role == nil:
+		return nil, noSuchEntity("role", params.RoleName)
+	default:
+		return &iam.GetRoleOutput{Role: role}, nil
+	}
+}
+
+func (r *dryRunIAM) GetUser(ctx context.Context, params *iam.GetUserInput, optFns ...func(*iam.Options)) (*iam.GetUserOutput, error) {
+	u := r.user(params.UserName)
+
+	switch {
+	case u == nil:
+		return r.IAM.GetUser(ctx, params, optFns...)
+	case u.deleted:
+		return nil, noSuchUser(params.UserName)
+	default:
+		return &iam.GetUserOutput{User: u.user}, nil
+	}
+}
+
+func (r *dryRunIAM) ListAccessKeys(ctx context.Context, params *iam.ListAccessKeysInput, optFns ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error) {
+	u := r.user(params.UserName)
+
+	switch {
+	case u == nil:
+		return r.IAM.ListAccessKeys(ctx, params, optFns...)
+	case u.deleted:
+		return nil, noSuchUser(params.UserName)
+	default:
+		return &iam.ListAccessKeysOutput{AccessKeyMetadata: u.accessKeys}, nil
+	}
+}
+
+func (r *dryRunIAM) ListGroupsForUser(ctx context.Context, params *iam.ListGroupsForUserInput, optFns ...func(*iam.Options)) (*iam.ListGroupsForUserOutput, error) {
+	u := r.user(params.UserName)
+
+	switch {
+	case u == nil:
+		return r.IAM.ListGroupsForUser(ctx, params, optFns...)
+	case u.deleted:
+		return nil, noSuchUser(params.UserName)
+	default:
+		return &iam.ListGroupsForUserOutput{Groups: u.groups}, nil
+	}
+}
+
+func (r *dryRunIAM) ListUserTags(ctx context.Context, params *iam.ListUserTagsInput, optFns ...func(*iam.Options)) (*iam.ListUserTagsOutput, error) {
+	u := r.user(params.UserName)
+
+	switch {
+	case u == nil:
+		return r.IAM.ListUserTags(ctx, params, optFns...)
+	case u.deleted:
+		return nil, noSuchUser(params.UserName)
+	default:
+		return &iam.ListUserTagsOutput{Tags: u.tags}, nil
+	}
+}
+
+func (r *dryRunIAM) ListUsers(ctx context.Context, params *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error) {
+	listed, err := r.IAM.ListUsers(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// users deleted in dry run are left out, and users created in dry run are added to the last page
+	users := slices.DeleteFunc(slices.Clone(listed.Users), func(user types.User) bool {
+		_, changed := r.users[aws.ToString(user.UserName)]
+		return changed
+	})
+
+	if !listed.IsTruncated {
+		for _, name := range slices.Sorted(maps.Keys(r.users)) {
+			user := r.users[name].user
+			if user != nil && strings.HasPrefix(aws.ToString(user.Path), aws.ToString(params.PathPrefix)) {
+				users = append(users, *user)
+			}
+		}
+	}
+
+	return &iam.ListUsersOutput{Users: users, IsTruncated: listed.IsTruncated, Marker: listed.Marker}, nil
+}
+
+func (r *dryRunIAM) RemoveUserFromGroup(_ context.Context, params *iam.RemoveUserFromGroupInput, _ ...func(*iam.Options)) (*iam.RemoveUserFromGroupOutput, error) {
+	logIntercepted("IAM.RemoveUserFromGroup", params)
+
+	r.change(params.UserName, func(u *dryRunUser) {
+		u.groups = slices.DeleteFunc(u.groups, func(g types.Group) bool {
+			return aws.ToString(g.GroupName) == aws.ToString(params.GroupName)
+		})
+	})
+
+	return &iam.RemoveUserFromGroupOutput{}, nil
+}
+
+func (r *dryRunIAM) TagUser(_ context.Context, params *iam.TagUserInput, _ ...func(*iam.Options)) (*iam.TagUserOutput, error) {
+	logIntercepted("IAM.TagUser", params)
+
+	r.change(params.UserName, func(u *dryRunUser) {
+		for _, tag := range params.Tags {
+			u.tags = slices.DeleteFunc(u.tags, func(existing types.Tag) bool {
+				return aws.ToString(existing.Key) == aws.ToString(tag.Key)
+			})
+			u.tags = append(u.tags, tag)
+		}
+	})
+
+	return &iam.TagUserOutput{}, nil
+}
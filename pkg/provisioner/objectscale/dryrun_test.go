@@ -0,0 +1,323 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+)
+
+// The mocks fail on every call without expectation, so the tests below verify that no mutating call is sent.
+
+func TestDryRunClientSet(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, "existing", mock.Anything).Return(&model.Bucket{Name: "existing"}, nil).Once()
+	bucketsMock.On("GetPolicy", mock.Anything, "existing", mock.Anything).Return(`{"Version":"2012-10-17"}`, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	client := &dryRunClientSet{ClientSet: mgmtClientMock, buckets: map[string]*dryRunBucket{}}
+
+	// reads of the unchanged buckets are sent to ObjectScale
+	bucket, err := client.Buckets().Get(ctx, "existing", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "existing", bucket.Name)
+
+	existingPolicy, err := client.Buckets().GetPolicy(ctx, "existing", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Version":"2012-10-17"}`, existingPolicy)
+
+	// created bucket is seen by the following reads
+	created, err := client.Buckets().Create(ctx, &model.ObjectBucketParam{Name: testBucketName, Namespace: testNamespace})
+	require.NoError(t, err)
+	assert.Equal(t, testBucketName, created.Name)
+
+	bucket, err = client.Buckets().Get(ctx, testBucketName, nil)
+	require.NoError(t, err)
+	assert.Equal(t, testNamespace, bucket.Namespace)
+
+	bucketPolicy, err := client.Buckets().GetPolicy(ctx, testBucketName, nil)
+	require.NoError(t, err)
+	assert.Empty(t, bucketPolicy)
+
+	require.NoError(t, client.Buckets().UpdatePolicy(ctx, testBucketName, `{"Id":"updated"}`, nil))
+	bucketPolicy, err = client.Buckets().GetPolicy(ctx, testBucketName, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Id":"updated"}`, bucketPolicy)
+
+	require.NoError(t, client.Buckets().DeletePolicy(ctx, "existing", nil))
+	existingPolicy, err = client.Buckets().GetPolicy(ctx, "existing", nil)
+	require.NoError(t, err)
+	assert.Empty(t, existingPolicy)
+
+	// deleted buckets are not found
+	require.NoError(t, client.Buckets().Delete(ctx, "existing", nil))
+	_, err = client.Buckets().Get(ctx, "existing", nil)
+	assert.ErrorIs(t, err, model.ErrParameterNotFound)
+
+	_, err = client.Buckets().GetPolicy(ctx, "existing", nil)
+	assert.ErrorIs(t, err, model.ErrParameterNotFound)
+}
+
+func TestDryRunIAM(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, &iam.GetUserInput{UserName: aws.String("existing")}).
+		Return(&iam.GetUserOutput{User: &types.User{UserName: aws.String("existing")}}, nil).Once()
+	iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+
+	factoryCalls := 0
+	iamClient := dryRunIAMClient(func(context.Context) (IAM, error) {
+		factoryCalls++
+		return iamMock, nil
+	})
+
+	client, err := iamClient(ctx)
+	require.NoError(t, err)
+
+	// the wrapper is shared by all operations
+	same, err := iamClient(ctx)
+	require.NoError(t, err)
+	assert.Same(t, client, same)
+	assert.Equal(t, 2, factoryCalls)
+
+	// reads of the unchanged users are sent to ObjectScale
+	existing, err := client.GetUser(ctx, &iam.GetUserInput{UserName: aws.String("existing")})
+	require.NoError(t, err)
+	assert.Equal(t, "existing", aws.ToString(existing.User.UserName))
+
+	_, err = client.GetGroup(ctx, &iam.GetGroupInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+
+	// created user is seen by the following reads
+	userName := aws.String("user")
+	tags := []types.Tag{{Key: aws.String("cosi.dellemc.com/access"), Value: aws.String("access")}}
+
+	created, err := client.CreateUser(ctx, &iam.CreateUserInput{UserName: userName, Tags: tags})
+	require.NoError(t, err)
+	assert.NotNil(t, created.User.UserId)
+
+	user, err := client.GetUser(ctx, &iam.GetUserInput{UserName: userName})
+	require.NoError(t, err)
+	assert.Equal(t, created.User, user.User)
+
+	_, err = client.TagUser(ctx, &iam.TagUserInput{UserName: userName, Tags: []types.Tag{{Key: aws.String("ttl"), Value: aws.String("1h")}}})
+	require.NoError(t, err)
+
+	userTags, err := client.ListUserTags(ctx, &iam.ListUserTagsInput{UserName: userName})
+	require.NoError(t, err)
+	assert.Len(t, userTags.Tags, 2)
+
+	accessKey, err := client.CreateAccessKey(ctx, &iam.CreateAccessKeyInput{UserName: userName})
+	require.NoError(t, err)
+	assert.Equal(t, dryRunSecretAccessKey, aws.ToString(accessKey.AccessKey.SecretAccessKey))
+
+	accessKeys, err := client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: userName})
+	require.NoError(t, err)
+	require.Len(t, accessKeys.AccessKeyMetadata, 1)
+	assert.Equal(t, accessKey.AccessKey.AccessKeyId, accessKeys.AccessKeyMetadata[0].AccessKeyId)
+
+	groups, err := client.ListGroupsForUser(ctx, &iam.ListGroupsForUserInput{UserName: userName})
+	require.NoError(t, err)
+	assert.Empty(t, groups.Groups)
+
+	_, err = client.DeleteAccessKey(ctx, &iam.DeleteAccessKeyInput{UserName: userName, AccessKeyId: accessKey.AccessKey.AccessKeyId})
+	require.NoError(t, err)
+
+	accessKeys, err = client.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: userName})
+	require.NoError(t, err)
+	assert.Empty(t, accessKeys.AccessKeyMetadata)
+
+	// deleted users are not found, including the users existing on ObjectScale
+	for _, name := range []*string{userName, aws.String("existing")} {
+		_, err = client.DeleteUser(ctx, &iam.DeleteUserInput{UserName: name})
+		require.NoError(t, err)
+
+		_, err = client.GetUser(ctx, &iam.GetUserInput{UserName: name})
+		assert.True(t, isNoSuchEntity(err))
+	}
+
+	// group, policy and role changes are not sent, and created groups and roles are seen by the following reads
+	_, err = client.CreateGroup(ctx, &iam.CreateGroupInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+
+	group, err := client.GetGroup(ctx, &iam.GetGroupInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+	assert.Equal(t, "group", aws.ToString(group.Group.GroupName))
+
+	_, err = client.CreatePolicy(ctx, &iam.CreatePolicyInput{PolicyName: aws.String("group"), PolicyDocument: aws.String("{}")})
+	require.NoError(t, err)
+	_, err = client.CreatePolicyVersion(ctx, &iam.CreatePolicyVersionInput{PolicyArn: aws.String("arn"), PolicyDocument: aws.String("{}")})
+	require.NoError(t, err)
+	_, err = client.DeletePolicyVersion(ctx, &iam.DeletePolicyVersionInput{PolicyArn: aws.String("arn"), VersionId: aws.String("v1")})
+	require.NoError(t, err)
+	_, err = client.AttachGroupPolicy(ctx, &iam.AttachGroupPolicyInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+
+	// memberships of the users created in dry run are tracked
+	member := aws.String("member")
+	_, err = client.CreateUser(ctx, &iam.CreateUserInput{UserName: member})
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = client.AddUserToGroup(ctx, &iam.AddUserToGroupInput{GroupName: aws.String("group"), UserName: member})
+		require.NoError(t, err)
+	}
+
+	groups, err = client.ListGroupsForUser(ctx, &iam.ListGroupsForUserInput{UserName: member})
+	require.NoError(t, err)
+	require.Len(t, groups.Groups, 1)
+	assert.Equal(t, "group", aws.ToString(groups.Groups[0].GroupName))
+
+	group, err = client.GetGroup(ctx, &iam.GetGroupInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+	require.Len(t, group.Users, 1)
+	assert.Equal(t, "member", aws.ToString(group.Users[0].UserName))
+
+	_, err = client.RemoveUserFromGroup(ctx, &iam.RemoveUserFromGroupInput{GroupName: aws.String("group"), UserName: member})
+	require.NoError(t, err)
+
+	groups, err = client.ListGroupsForUser(ctx, &iam.ListGroupsForUserInput{UserName: member})
+	require.NoError(t, err)
+	assert.Empty(t, groups.Groups)
+
+	_, err = client.DetachGroupPolicy(ctx, &iam.DetachGroupPolicyInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+	_, err = client.DeleteGroup(ctx, &iam.DeleteGroupInput{GroupName: aws.String("group")})
+	require.NoError(t, err)
+
+	_, err = client.GetGroup(ctx, &iam.GetGroupInput{GroupName: aws.String("group")})
+	assert.True(t, isNoSuchEntity(err))
+
+	_, err = client.DeletePolicy(ctx, &iam.DeletePolicyInput{PolicyArn: aws.String("arn")})
+	require.NoError(t, err)
+	role, err := client.CreateRole(ctx, &iam.CreateRoleInput{RoleName: aws.String("role")})
+	require.NoError(t, err)
+	assert.Equal(t, "role", aws.ToString(role.Role.RoleName))
+
+	got, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String("role")})
+	require.NoError(t, err)
+	assert.Equal(t, role.Role, got.Role)
+
+	_, err = client.DeleteRole(ctx, &iam.DeleteRoleInput{RoleName: aws.String("role")})
+	require.NoError(t, err)
+
+	_, err = client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String("role")})
+	assert.True(t, isNoSuchEntity(err))
+}
+
+func TestDryRunIAMListUsers(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/")}).Return(&iam.ListUsersOutput{
+		Users:       []types.User{{UserName: aws.String("existing")}, {UserName: aws.String("deleted")}},
+		IsTruncated: true,
+		Marker:      aws.String("marker"),
+	}, nil).Once()
+	iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/"), Marker: aws.String("marker")}).
+		Return(&iam.ListUsersOutput{Users: []types.User{{UserName: aws.String("last")}}}, nil).Once()
+
+	client, err := dryRunIAMClient(func(context.Context) (IAM, error) { return iamMock, nil })(ctx)
+	require.NoError(t, err)
+
+	_, err = client.CreateUser(ctx, &iam.CreateUserInput{UserName: aws.String("created"), Path: aws.String("/cosi/cluster/")})
+	require.NoError(t, err)
+	_, err = client.CreateUser(ctx, &iam.CreateUserInput{UserName: aws.String("other"), Path: aws.String("/other/")})
+	require.NoError(t, err)
+	_, err = client.DeleteUser(ctx, &iam.DeleteUserInput{UserName: aws.String("deleted")})
+	require.NoError(t, err)
+
+	userNames := func(users []types.User) []string {
+		names := []string{}
+		for _, user := range users {
+			names = append(names, aws.ToString(user.UserName))
+		}
+
+		return names
+	}
+
+	// users deleted in dry run are not listed, and users created in dry run are listed on the last page
+	first, err := client.ListUsers(ctx, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"existing"}, userNames(first.Users))
+	assert.True(t, first.IsTruncated)
+
+	last, err := client.ListUsers(ctx, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/"), Marker: first.Marker})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"last", "created"}, userNames(last.Users))
+	assert.False(t, last.IsTruncated)
+}
+
+func TestServerDryRun(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	// bucket does not exist before it is created in dry run
+	bucketsMock.On("Get", mock.Anything, testBucketName, mock.Anything).Return(nil, model.ErrParameterNotFound).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+	iamMock := omocks.NewIAM(t)
+	// user does not exist before it is created in dry run
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+
+	server := &Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		clusterID:  testClusterID,
+		s3Endpoint: "https://s3.example.com",
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+	}
+	server.EnableDryRun()
+
+	// the whole lifecycle of the bucket and its access progresses, without changing ObjectScale
+	bucket, err := server.DriverCreateBucket(ctx, &cosi.DriverCreateBucketRequest{Name: testBucketName})
+	require.NoError(t, err)
+
+	access, err := server.DriverGrantBucketAccess(ctx, &cosi.DriverGrantBucketAccessRequest{
+		BucketId:           bucket.BucketId,
+		Name:               "access",
+		AuthenticationType: cosi.AuthenticationType_Key,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dryRunSecretAccessKey, access.Credentials["s3"].Secrets["accessSecretKey"])
+
+	_, err = server.DriverRevokeBucketAccess(ctx, &cosi.DriverRevokeBucketAccessRequest{
+		BucketId:  bucket.BucketId,
+		AccountId: access.AccountId,
+	})
+	require.NoError(t, err)
+
+	_, err = server.DriverDeleteBucket(ctx, &cosi.DriverDeleteBucketRequest{BucketId: bucket.BucketId})
+	require.NoError(t, err)
+}
@@ -0,0 +1,213 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/smithy-go"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ErrorDomain is the domain of the ErrorInfo details, attached to the statuses of the failures reported by ObjectScale.
+	// Reason of the details is the error code returned by ObjectScale.
+	ErrorDomain = "cosi.dellemc.com"
+
+	// ErrorBackendKey is the metadata key of the ErrorInfo details, with the ObjectScale API returning the error.
+	ErrorBackendKey = "backend"
+
+	managementBackend = "management"
+	iamBackend        = "iam"
+
+	// objectscaleQuotaExceeded is the error code of the ObjectScale management API, returned when the quota
+	// of the namespace or the limit of buckets is exceeded. It is not defined by goobjectscale.
+	objectscaleQuotaExceeded int64 = 6413
+	// objectscaleThrottled is the error code of the ObjectScale management API, returned when the request
+	// is rejected over the rate limit of ObjectScale. It is not defined by goobjectscale.
+	objectscaleThrottled int64 = 6429
+)
+
+// objectscaleErrorCodes map error codes of the ObjectScale management API to gRPC codes.
+var objectscaleErrorCodes = map[int64]codes.Code{
+	model.ErrParameterNotFound.Code: codes.NotFound,
+	model.ErrEntityNotFound.Code:    codes.NotFound,
+	objectscaleQuotaExceeded:        codes.ResourceExhausted,
+	objectscaleThrottled:            codes.ResourceExhausted,
+}
+
+// iamErrorCodes map error codes of the ObjectScale IAM API to gRPC codes.
+var iamErrorCodes = map[string]codes.Code{
+	"AccessDenied":            codes.PermissionDenied,
+	"AccessDeniedException":   codes.PermissionDenied,
+	"InvalidClientTokenId":    codes.PermissionDenied,
+	"SignatureDoesNotMatch":   codes.PermissionDenied,
+	"NoSuchEntity":            codes.NotFound,
+	"EntityAlreadyExists":     codes.AlreadyExists,
+	"LimitExceeded":           codes.ResourceExhausted,
+	"QuotaExceeded":           codes.ResourceExhausted,
+	"Throttling":              codes.ResourceExhausted,
+	"ThrottlingException":     codes.ResourceExhausted,
+	"RequestLimitExceeded":    codes.ResourceExhausted,
+	"TooManyRequests":         codes.ResourceExhausted,
+	"SlowDown":                codes.ResourceExhausted,
+	"InvalidInput":            codes.InvalidArgument,
+	"InvalidParameterValue":   codes.InvalidArgument,
+	"MalformedPolicyDocument": codes.InvalidArgument,
+	"ValidationError":         codes.InvalidArgument,
+	"DeleteConflict":          codes.FailedPrecondition,
+	"ConcurrentModification":  codes.Aborted,
+	"ServiceFailure":          codes.Unavailable,
+	"ServiceUnavailable":      codes.Unavailable,
+}
+
+// httpStatusCodes map HTTP statuses of the responses without a known error code to gRPC codes.
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.PermissionDenied,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.Unavailable,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// backendError is the failure reported by ObjectScale.
+type backendError struct {
+	// backend is the API returning the error, either management or IAM.
+	backend string
+	// reason is the error code returned by ObjectScale.
+	reason string
+	// code is the gRPC code corresponding to the error code, Unknown if there is none.
+	code codes.Code
+}
+
+// classifyError returns the gRPC code of the failure, and the backend error causing it, if any.
+// Code chosen by the caller is kept, unless it is Internal, which is refined using the context and the error.
+func classifyError(ctx context.Context, err error, code codes.Code) (codes.Code, *backendError) {
+	backendErr := newBackendError(err)
+
+	if code != codes.Internal || err == nil {
+		return code, backendErr
+	}
+
+	var exhausted *retriesExhaustedError
+
+	switch {
+	// Failures caused by the expired deadline of the operation are reported as such, so they are retried.
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return codes.DeadlineExceeded, backendErr
+	case errors.Is(ctx.Err(), context.Canceled):
+		return codes.Canceled, backendErr
+	// Transient failures, which persisted after all retries, are reported as unavailability of ObjectScale.
+	case errors.As(err, &exhausted):
+		return codes.Unavailable, backendErr
+	case backendErr != nil && backendErr.code != codes.Unknown:
+		return backendErr.code, backendErr
+	default:
+		return code, backendErr
+	}
+}
+
+// newBackendError extracts the failure reported by ObjectScale from the error. Nil is returned for other errors.
+func newBackendError(err error) *backendError {
+	var (
+		apiErr    smithy.APIError
+		statusErr interface{ HTTPStatusCode() int }
+	)
+
+	objectscaleErr, isObjectscaleErr := asObjectscaleError(err)
+
+	switch {
+	case isObjectscaleErr:
+		code, ok := objectscaleErrorCodes[objectscaleErr.Code]
+		if !ok {
+			code = codes.Unknown
+			if objectscaleErr.Retryable {
+				code = codes.Unavailable
+			}
+		}
+
+		return &backendError{backend: managementBackend, reason: strconv.FormatInt(objectscaleErr.Code, 10), code: code}
+
+	case errors.Is(err, client.ErrAuthorization):
+		return &backendError{backend: managementBackend, reason: http.StatusText(http.StatusUnauthorized), code: codes.PermissionDenied}
+
+	case errors.As(err, &apiErr):
+		if code, ok := iamErrorCodes[apiErr.ErrorCode()]; ok {
+			return &backendError{backend: iamBackend, reason: apiErr.ErrorCode(), code: code}
+		}
+
+		backendErr := &backendError{backend: iamBackend, reason: apiErr.ErrorCode(), code: codes.Unknown}
+		if errors.As(err, &statusErr) {
+			if code, ok := httpStatusCodes[statusErr.HTTPStatusCode()]; ok {
+				backendErr.code = code
+			}
+		}
+
+		return backendErr
+
+	case errors.As(err, &statusErr):
+		code, ok := httpStatusCodes[statusErr.HTTPStatusCode()]
+		if !ok {
+			code = codes.Unknown
+		}
+
+		return &backendError{backend: iamBackend, reason: strconv.Itoa(statusErr.HTTPStatusCode()), code: code}
+	}
+
+	return nil
+}
+
+// asObjectscaleError returns the error of the ObjectScale management API wrapped in err, if any.
+// goobjectscale returns the errors of the responses as pointers, and the predefined errors as values.
+func asObjectscaleError(err error) (model.Error, bool) {
+	var objectscaleErrPtr *model.Error
+	if errors.As(err, &objectscaleErrPtr) && objectscaleErrPtr != nil {
+		return *objectscaleErrPtr, true
+	}
+
+	var objectscaleErr model.Error
+	if errors.As(err, &objectscaleErr) {
+		return objectscaleErr, true
+	}
+
+	return model.Error{}, false
+}
+
+// statusError creates the gRPC status error, with the backend error attached as ErrorInfo details.
+func statusError(code codes.Code, msg string, backendErr *backendError) error {
+	st := status.New(code, msg)
+	if backendErr == nil {
+		return st.Err()
+	}
+
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   backendErr.reason,
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{ErrorBackendKey: backendErr.backend},
+	})
+	if err != nil {
+		log.Warnf("failed attaching error details: %v", err)
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,250 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/smithy-go"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyError(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		err            error
+		code           codes.Code
+		expectedCode   codes.Code
+		expectedReason string
+	}{
+		{
+			name:         "no error",
+			err:          nil,
+			code:         codes.Internal,
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "unknown error",
+			err:          errors.New("failed"),
+			code:         codes.Internal,
+			expectedCode: codes.Internal,
+		},
+		{
+			name:           "code chosen by caller",
+			err:            &types.NoSuchEntityException{},
+			code:           codes.InvalidArgument,
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: "NoSuchEntity",
+		},
+		{
+			name:           "ObjectScale not found",
+			err:            fmt.Errorf("failed getting bucket: %w", model.ErrParameterNotFound),
+			code:           codes.Internal,
+			expectedCode:   codes.NotFound,
+			expectedReason: "1004",
+		},
+		{
+			name:           "ObjectScale retryable error",
+			err:            model.Error{Code: 6503, Retryable: true},
+			code:           codes.Internal,
+			expectedCode:   codes.Unavailable,
+			expectedReason: "6503",
+		},
+		{
+			name:           "ObjectScale error returned by the client",
+			err:            fmt.Errorf("server error: %w", &model.Error{Code: 2000}),
+			code:           codes.Internal,
+			expectedCode:   codes.NotFound,
+			expectedReason: "2000",
+		},
+		{
+			name:           "ObjectScale quota exceeded",
+			err:            fmt.Errorf("server error: %w", &model.Error{Code: objectscaleQuotaExceeded}),
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "6413",
+		},
+		{
+			name:           "ObjectScale throttled",
+			err:            model.Error{Code: objectscaleThrottled},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "6429",
+		},
+		{
+			name:           "ObjectScale unknown error",
+			err:            model.Error{Code: 1008},
+			code:           codes.Internal,
+			expectedCode:   codes.Internal,
+			expectedReason: "1008",
+		},
+		{
+			name:           "ObjectScale authorization",
+			err:            fmt.Errorf("%w: retry login", client.ErrAuthorization),
+			code:           codes.Internal,
+			expectedCode:   codes.PermissionDenied,
+			expectedReason: "Unauthorized",
+		},
+		{
+			name:           "IAM entity already exists",
+			err:            &types.EntityAlreadyExistsException{},
+			code:           codes.Internal,
+			expectedCode:   codes.AlreadyExists,
+			expectedReason: "EntityAlreadyExists",
+		},
+		{
+			name:           "IAM limit exceeded",
+			err:            &types.LimitExceededException{},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "LimitExceeded",
+		},
+		{
+			name:           "IAM QuotaExceeded",
+			err:            &smithy.GenericAPIError{Code: "QuotaExceeded"},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "QuotaExceeded",
+		},
+		{
+			name:           "IAM Throttling",
+			err:            &smithy.GenericAPIError{Code: "Throttling"},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "Throttling",
+		},
+		{
+			name:           "IAM ThrottlingException",
+			err:            &smithy.GenericAPIError{Code: "ThrottlingException"},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "ThrottlingException",
+		},
+		{
+			name:           "IAM RequestLimitExceeded",
+			err:            &smithy.GenericAPIError{Code: "RequestLimitExceeded"},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "RequestLimitExceeded",
+		},
+		{
+			name:           "IAM TooManyRequests",
+			err:            &smithy.GenericAPIError{Code: "TooManyRequests"},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "TooManyRequests",
+		},
+		{
+			name:           "IAM SlowDown",
+			err:            &smithy.GenericAPIError{Code: "SlowDown"},
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "SlowDown",
+		},
+		{
+			name:           "IAM malformed policy",
+			err:            &types.MalformedPolicyDocumentException{},
+			code:           codes.Internal,
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: "MalformedPolicyDocument",
+		},
+		{
+			name:           "IAM access denied",
+			err:            &smithy.GenericAPIError{Code: "AccessDenied"},
+			code:           codes.Internal,
+			expectedCode:   codes.PermissionDenied,
+			expectedReason: "AccessDenied",
+		},
+		{
+			name:           "HTTP too many requests",
+			err:            httpStatusError(http.StatusTooManyRequests),
+			code:           codes.Internal,
+			expectedCode:   codes.ResourceExhausted,
+			expectedReason: "429",
+		},
+		{
+			name:           "HTTP forbidden",
+			err:            httpStatusError(http.StatusForbidden),
+			code:           codes.Internal,
+			expectedCode:   codes.PermissionDenied,
+			expectedReason: "403",
+		},
+		{
+			name:           "retries exhausted",
+			err:            &retriesExhaustedError{attempts: 3, err: httpStatusError(http.StatusInternalServerError)},
+			code:           codes.Internal,
+			expectedCode:   codes.Unavailable,
+			expectedReason: "500",
+		},
+		{
+			name:         "deadline exceeded",
+			ctx:          expired,
+			err:          errors.New("failed"),
+			code:         codes.Internal,
+			expectedCode: codes.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			code, backendErr := classifyError(ctx, tt.err, tt.code)
+			assert.Equal(t, tt.expectedCode, code)
+
+			if tt.expectedReason == "" {
+				assert.Nil(t, backendErr)
+				return
+			}
+
+			require.NotNil(t, backendErr)
+			assert.Equal(t, tt.expectedReason, backendErr.reason)
+		})
+	}
+}
+
+func TestLogAndTraceErrorDetails(t *testing.T) {
+	ctx := context.Background()
+
+	err := logAndTraceError(ctx, trace.SpanFromContext(ctx), "failed creating user", &types.EntityAlreadyExistsException{}, codes.Internal)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Equal(t, "failed creating user", st.Message())
+	require.Len(t, st.Details(), 1)
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, ErrorDomain, info.Domain)
+	assert.Equal(t, "EntityAlreadyExists", info.Reason)
+	assert.Equal(t, iamBackend, info.Metadata[ErrorBackendKey])
+
+	// errors not reported by ObjectScale have no details
+	err = logAndTraceError(ctx, trace.SpanFromContext(ctx), "failed", errors.New("failed"), codes.Internal)
+	assert.Equal(t, status.Error(codes.Internal, "failed"), err)
+}
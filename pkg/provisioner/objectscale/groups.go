@@ -0,0 +1,324 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"go.opentelemetry.io/otel"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+const (
+	// AccessModeParam is the BucketAccessClass parameter selecting permissions granted on the bucket.
+	AccessModeParam = "accessMode"
+	// AccessModeReadWrite grants all permissions on the bucket. It is the default access mode.
+	AccessModeReadWrite = "ReadWrite"
+	// AccessModeReadOnly grants permissions to list and read objects from the bucket.
+	AccessModeReadOnly = "ReadOnly"
+
+	// groupPrefix is the prefix of IAM groups and managed policies created for the buckets.
+	groupPrefix = "cosi-"
+)
+
+// readOnlyActions are actions granted in the ReadOnly access mode.
+var readOnlyActions = []string{
+	"s3:GetBucketLocation",
+	"s3:GetObject",
+	"s3:GetObjectVersion",
+	"s3:ListBucket",
+	"s3:ListBucketVersions",
+}
+
+// parseAccessMode returns access mode requested in the BucketAccessClass parameters.
+func parseAccessMode(parameters map[string]string) (string, error) {
+	switch mode := strings.TrimSpace(parameters[AccessModeParam]); mode {
+	case "", AccessModeReadWrite:
+		return AccessModeReadWrite, nil
+	case AccessModeReadOnly:
+		return AccessModeReadOnly, nil
+	default:
+		return "", fmt.Errorf("invalid access mode %s, expected one of %s, %s", mode, AccessModeReadWrite, AccessModeReadOnly)
+	}
+}
+
+// accessModeActions returns actions granted in the access mode.
+func accessModeActions(mode string) []string {
+	if mode == AccessModeReadOnly {
+		return readOnlyActions
+	}
+
+	return []string{"*"}
+}
+
+// BuildGroupName builds name of the IAM group and managed policy for the bucket and access mode.
+func BuildGroupName(bucketName, accessMode string) string {
+	return fmt.Sprintf("%s%s-%s", groupPrefix, bucketName, strings.ToLower(accessMode))
+}
+
+// BuildPolicyARN builds ARN of the managed policy.
+func BuildPolicyARN(policyName, namespace string) string {
+	return fmt.Sprintf("urn:ecs:iam::%s:policy/%s", namespace, policyName)
+}
+
+// groupAccessManagement checks if bucket access is granted using IAM groups instead of bucket policy.
+func (s *Server) groupAccessManagement() bool {
+	return s.accessManagement == obsConfig.ObjectscaleAccessManagementGroup
+}
+
+// validateGroupAccessParameters rejects parameters, which cannot be applied to the access shared by the group.
+func validateGroupAccessParameters(parameters map[string]string) error {
+	for _, param := range []string{PolicyTemplateParam, AllowedSourceCIDRsParam, UserNamespaceParam} {
+		if strings.TrimSpace(parameters[param]) != "" {
+			return fmt.Errorf("parameter %s is not supported with group access management", param)
+		}
+	}
+
+	return nil
+}
+
+// addUserToBucketGroup adds the user to the group of the bucket and access mode.
+// The group and its managed policy are created, if they do not exist yet.
+func (s *Server) addUserToBucketGroup(ctx context.Context, iamClient IAM, bucketName, userName, accessMode string) error {
+	ctx, span := otel.Tracer(GrantBucketAccessTraceName).Start(ctx, "ObjectscaleAddUserToBucketGroup")
+	defer span.End()
+
+	groupName := BuildGroupName(bucketName, accessMode)
+
+	_, err := iamClient.GetGroup(ctx, &iam.GetGroupInput{GroupName: &groupName})
+	if err != nil {
+		if !isNoSuchEntity(err) {
+			return fmt.Errorf("failed getting group %s: %w", groupName, err)
+		}
+
+		_, err = iamClient.CreateGroup(ctx, &iam.CreateGroupInput{GroupName: &groupName})
+		if err != nil && !isEntityAlreadyExists(err) {
+			return fmt.Errorf("failed creating group %s: %w", groupName, err)
+		}
+
+		log.Infof("Created ObjectScale IAM group %s", groupName)
+	}
+
+	statements := []policy.StatementEntry{{
+		Sid:      PolicySid,
+		Effect:   allowEffect,
+		Action:   accessModeActions(accessMode),
+		Resource: BuildResourceStrings(bucketName),
+	}}
+	restrictSourceIP(statements, s.allowedSourceCIDRs)
+
+	document := policy.Document{Version: bucketVersion, Statement: statements}
+
+	rawDocument, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed marshalling policy %s: %w", groupName, err)
+	}
+
+	policyARN := BuildPolicyARN(groupName, s.namespace)
+
+	// Policy is created with the same name as the group, so it may already exist after partially failed grant,
+	// or from the grant with the previous configuration, e.g. before the allowed source CIDRs changed.
+	_, err = iamClient.CreatePolicy(ctx, &iam.CreatePolicyInput{
+		PolicyName:     &groupName,
+		PolicyDocument: aws.String(string(rawDocument)),
+	})
+	switch {
+	case err == nil:
+	case isEntityAlreadyExists(err):
+		if err := updateManagedPolicy(ctx, iamClient, policyARN, document, string(rawDocument)); err != nil {
+			return fmt.Errorf("failed updating policy %s: %w", groupName, err)
+		}
+	default:
+		return fmt.Errorf("failed creating policy %s: %w", groupName, err)
+	}
+
+	_, err = iamClient.AttachGroupPolicy(ctx, &iam.AttachGroupPolicyInput{
+		GroupName: &groupName,
+		PolicyArn: &policyARN,
+	})
+	if err != nil {
+		return fmt.Errorf("failed attaching policy to group %s: %w", groupName, err)
+	}
+
+	_, err = iamClient.AddUserToGroup(ctx, &iam.AddUserToGroupInput{
+		GroupName: &groupName,
+		UserName:  &userName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed adding user to group %s: %w", groupName, err)
+	}
+
+	return nil
+}
+
+// updateManagedPolicy makes the document the default version of the existing managed policy, unless the default
+// version already grants the same. The replaced version is deleted, as the number of policy versions is limited.
+func updateManagedPolicy(ctx context.Context, iamClient IAM, policyARN string, document policy.Document, rawDocument string) error {
+	existing, err := iamClient.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: &policyARN})
+	if err != nil {
+		return err
+	}
+
+	if existing.Policy == nil || existing.Policy.DefaultVersionId == nil {
+		return fmt.Errorf("policy %s has no default version", policyARN)
+	}
+
+	defaultVersionID := existing.Policy.DefaultVersionId
+
+	version, err := iamClient.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: &policyARN,
+		VersionId: defaultVersionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if version.PolicyVersion != nil && sameManagedPolicy(aws.ToString(version.PolicyVersion.Document), document) {
+		return nil
+	}
+
+	_, err = iamClient.CreatePolicyVersion(ctx, &iam.CreatePolicyVersionInput{
+		PolicyArn:      &policyARN,
+		PolicyDocument: &rawDocument,
+		SetAsDefault:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Updated ObjectScale IAM policy %s, replacing version %s", policyARN, aws.ToString(defaultVersionID))
+
+	_, err = iamClient.DeletePolicyVersion(ctx, &iam.DeletePolicyVersionInput{
+		PolicyArn: &policyARN,
+		VersionId: defaultVersionID,
+	})
+	if err != nil && !isNoSuchEntity(err) {
+		return err
+	}
+
+	return nil
+}
+
+// sameManagedPolicy checks if the policy version document, which IAM returns URL-encoded, grants the same
+// as the document. The document, which cannot be parsed, is replaced.
+func sameManagedPolicy(versionDocument string, document policy.Document) bool {
+	if decoded, err := url.PathUnescape(versionDocument); err == nil {
+		versionDocument = decoded
+	}
+
+	existing, err := policy.NewFromJSON(versionDocument)
+	if err != nil {
+		return false
+	}
+
+	return existing.Equal(&document)
+}
+
+// deleteBucketGroups deletes groups and managed policies created for the bucket in all access modes.
+func (s *Server) deleteBucketGroups(ctx context.Context, iamClient IAM, bucketName string) error {
+	ctx, span := otel.Tracer(DeleteBucketTraceName).Start(ctx, "ObjectscaleDeleteBucketGroups")
+	defer span.End()
+
+	for _, accessMode := range []string{AccessModeReadWrite, AccessModeReadOnly} {
+		groupName := BuildGroupName(bucketName, accessMode)
+		policyARN := BuildPolicyARN(groupName, s.namespace)
+
+		_, err := iamClient.DetachGroupPolicy(ctx, &iam.DetachGroupPolicyInput{
+			GroupName: &groupName,
+			PolicyArn: &policyARN,
+		})
+		if err != nil && !isNoSuchEntity(err) {
+			return fmt.Errorf("failed detaching policy from group %s: %w", groupName, err)
+		}
+
+		// The group cannot be deleted while it has members, e.g. users of the access not revoked yet.
+		err = removeGroupMembers(ctx, iamClient, groupName)
+		if err != nil {
+			return fmt.Errorf("failed removing members from group %s: %w", groupName, err)
+		}
+
+		_, err = iamClient.DeleteGroup(ctx, &iam.DeleteGroupInput{GroupName: &groupName})
+		if err != nil && !isNoSuchEntity(err) {
+			return fmt.Errorf("failed deleting group %s: %w", groupName, err)
+		}
+
+		_, err = iamClient.DeletePolicy(ctx, &iam.DeletePolicyInput{PolicyArn: &policyARN})
+		if err != nil && !isNoSuchEntity(err) {
+			return fmt.Errorf("failed deleting policy %s: %w", groupName, err)
+		}
+	}
+
+	return nil
+}
+
+// removeGroupMembers removes all users from the group. It does nothing, if the group does not exist.
+func removeGroupMembers(ctx context.Context, iamClient IAM, groupName string) error {
+	group, err := iamClient.GetGroup(ctx, &iam.GetGroupInput{GroupName: &groupName})
+	if err != nil {
+		if isNoSuchEntity(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, user := range group.Users {
+		_, err = iamClient.RemoveUserFromGroup(ctx, &iam.RemoveUserFromGroupInput{
+			GroupName: &groupName,
+			UserName:  user.UserName,
+		})
+		if err != nil && !isNoSuchEntity(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeUserFromGroups removes the user from all groups, as the user cannot be deleted while being a group member.
+func removeUserFromGroups(ctx context.Context, iamClient IAM, userName string) error {
+	groups, err := iamClient.ListGroupsForUser(ctx, &iam.ListGroupsForUserInput{UserName: &userName})
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups.Groups {
+		_, err = iamClient.RemoveUserFromGroup(ctx, &iam.RemoveUserFromGroupInput{
+			GroupName: group.GroupName,
+			UserName:  &userName,
+		})
+		if err != nil && !isNoSuchEntity(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isNoSuchEntity checks if the IAM error indicates that the entity does not exist.
+func isNoSuchEntity(err error) bool {
+	var noSuchEntity *types.NoSuchEntityException
+	return errors.As(err, &noSuchEntity)
+}
+
+// isEntityAlreadyExists checks if the IAM error indicates that the entity already exists.
+func isEntityAlreadyExists(err error) bool {
+	var alreadyExists *types.EntityAlreadyExistsException
+	return errors.As(err, &alreadyExists)
+}
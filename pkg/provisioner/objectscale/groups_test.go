@@ -0,0 +1,240 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+func TestParseAccessMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		expected   string
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			parameters: map[string]string{},
+			expected:   AccessModeReadWrite,
+		},
+		{
+			name:       "read only",
+			parameters: map[string]string{AccessModeParam: AccessModeReadOnly},
+			expected:   AccessModeReadOnly,
+		},
+		{
+			name:       "invalid",
+			parameters: map[string]string{AccessModeParam: "WriteOnly"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := parseAccessMode(tt.parameters)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+}
+
+func TestValidateGroupAccessParameters(t *testing.T) {
+	assert.NoError(t, validateGroupAccessParameters(map[string]string{AccessModeParam: AccessModeReadOnly, TTLParam: "1h"}))
+
+	for _, param := range []string{PolicyTemplateParam, AllowedSourceCIDRsParam, UserNamespaceParam} {
+		assert.Error(t, validateGroupAccessParameters(map[string]string{param: "value"}), param)
+	}
+}
+
+func TestAddUserToBucketGroup(t *testing.T) {
+	groupName := BuildGroupName(testBucketName, AccessModeReadOnly)
+	policyARN := BuildPolicyARN(groupName, testNamespace)
+
+	readOnlyGroupPolicy, err := json.Marshal(policy.Document{Version: bucketVersion, Statement: []policy.StatementEntry{{
+		Sid:       PolicySid,
+		Effect:    allowEffect,
+		Action:    readOnlyActions,
+		Resource:  BuildResourceStrings(testBucketName),
+		Condition: policy.Condition{"IpAddress": {"aws:SourceIp": {"10.0.0.0/8"}}},
+	}}})
+	require.NoError(t, err)
+
+	for scenario, fn := range map[string]func(t *testing.T, iamMock *omocks.IAM) error{
+		"GroupCreated": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, &iam.GetGroupInput{GroupName: aws.String(groupName)}).Return(nil, &types.NoSuchEntityException{}).Once()
+			iamMock.On("CreateGroup", mock.Anything, &iam.CreateGroupInput{GroupName: aws.String(groupName)}).Return(&iam.CreateGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.MatchedBy(func(input *iam.CreatePolicyInput) bool {
+				doc, err := policy.NewFromJSON(aws.ToString(input.PolicyDocument))
+				if err != nil || len(doc.Statement) != 1 {
+					return false
+				}
+
+				return aws.ToString(input.PolicyName) == groupName &&
+					doc.Statement[0].Principal == nil &&
+					grantsAction(doc.Statement[0], "s3:GetObject") &&
+					!grantsAction(doc.Statement[0], "s3:PutObject") &&
+					doc.Statement[0].Condition.Equal(policy.Condition{"IpAddress": {"aws:SourceIp": {"10.0.0.0/8"}}})
+			})).Return(&iam.CreatePolicyOutput{}, nil).Once()
+			iamMock.On("AttachGroupPolicy", mock.Anything, &iam.AttachGroupPolicyInput{
+				GroupName: aws.String(groupName),
+				PolicyArn: aws.String(policyARN),
+			}).Return(&iam.AttachGroupPolicyOutput{}, nil).Once()
+			iamMock.On("AddUserToGroup", mock.Anything, &iam.AddUserToGroupInput{
+				GroupName: aws.String(groupName),
+				UserName:  aws.String("user"),
+			}).Return(&iam.AddUserToGroupOutput{}, nil).Once()
+
+			return nil
+		},
+		"GroupAndPolicyExist": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(nil, &types.EntityAlreadyExistsException{}).Once()
+			iamMock.On("GetPolicy", mock.Anything, &iam.GetPolicyInput{PolicyArn: aws.String(policyARN)}).Return(&iam.GetPolicyOutput{
+				Policy: &types.Policy{DefaultVersionId: aws.String("v1")},
+			}, nil).Once()
+			// the document is returned URL-encoded, with the same statement
+			iamMock.On("GetPolicyVersion", mock.Anything, &iam.GetPolicyVersionInput{
+				PolicyArn: aws.String(policyARN),
+				VersionId: aws.String("v1"),
+			}).Return(&iam.GetPolicyVersionOutput{
+				PolicyVersion: &types.PolicyVersion{Document: aws.String(url.PathEscape(string(readOnlyGroupPolicy)))},
+			}, nil).Once()
+			iamMock.On("AttachGroupPolicy", mock.Anything, mock.Anything).Return(&iam.AttachGroupPolicyOutput{}, nil).Once()
+			iamMock.On("AddUserToGroup", mock.Anything, mock.Anything).Return(&iam.AddUserToGroupOutput{}, nil).Once()
+
+			return nil
+		},
+		"PolicyUpdated": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(nil, &types.EntityAlreadyExistsException{}).Once()
+			iamMock.On("GetPolicy", mock.Anything, mock.Anything).Return(&iam.GetPolicyOutput{
+				Policy: &types.Policy{DefaultVersionId: aws.String("v1")},
+			}, nil).Once()
+			// the policy was created before the allowed source CIDRs were configured
+			iamMock.On("GetPolicyVersion", mock.Anything, mock.Anything).Return(&iam.GetPolicyVersionOutput{
+				PolicyVersion: &types.PolicyVersion{Document: aws.String(`{"Version":"2012-10-17","Statement":[]}`)},
+			}, nil).Once()
+			iamMock.On("CreatePolicyVersion", mock.Anything, mock.MatchedBy(func(input *iam.CreatePolicyVersionInput) bool {
+				doc, err := policy.NewFromJSON(aws.ToString(input.PolicyDocument))
+				if err != nil || len(doc.Statement) != 1 {
+					return false
+				}
+
+				return aws.ToString(input.PolicyArn) == policyARN && input.SetAsDefault &&
+					doc.Statement[0].Condition.Equal(policy.Condition{"IpAddress": {"aws:SourceIp": {"10.0.0.0/8"}}})
+			})).Return(&iam.CreatePolicyVersionOutput{}, nil).Once()
+			iamMock.On("DeletePolicyVersion", mock.Anything, &iam.DeletePolicyVersionInput{
+				PolicyArn: aws.String(policyARN),
+				VersionId: aws.String("v1"),
+			}).Return(&iam.DeletePolicyVersionOutput{}, nil).Once()
+			iamMock.On("AttachGroupPolicy", mock.Anything, mock.Anything).Return(&iam.AttachGroupPolicyOutput{}, nil).Once()
+			iamMock.On("AddUserToGroup", mock.Anything, mock.Anything).Return(&iam.AddUserToGroupOutput{}, nil).Once()
+
+			return nil
+		},
+		"ErrorUpdatingPolicy": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(nil, &types.EntityAlreadyExistsException{}).Once()
+			iamMock.On("GetPolicy", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed updating policy")
+		},
+		"ErrorGettingGroup": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed getting group")
+		},
+		"ErrorCreatingGroup": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+			iamMock.On("CreateGroup", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed creating group")
+		},
+		"ErrorCreatingPolicy": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed creating policy")
+		},
+		"ErrorAttachingPolicy": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(&iam.CreatePolicyOutput{}, nil).Once()
+			iamMock.On("AttachGroupPolicy", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed attaching policy")
+		},
+		"ErrorAddingUser": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetGroup", mock.Anything, mock.Anything).Return(&iam.GetGroupOutput{}, nil).Once()
+			iamMock.On("CreatePolicy", mock.Anything, mock.Anything).Return(&iam.CreatePolicyOutput{}, nil).Once()
+			iamMock.On("AttachGroupPolicy", mock.Anything, mock.Anything).Return(&iam.AttachGroupPolicyOutput{}, nil).Once()
+			iamMock.On("AddUserToGroup", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed adding user to group")
+		},
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			iamMock := omocks.NewIAM(t)
+			wantErr := fn(t, iamMock)
+
+			server := Server{
+				namespace:          testNamespace,
+				allowedSourceCIDRs: []string{"10.0.0.0/8"},
+			}
+
+			err := server.addUserToBucketGroup(ctx, iamMock, testBucketName, "user", AccessModeReadOnly)
+			if wantErr != nil {
+				assert.ErrorContains(t, err, wantErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRemoveUserFromGroups(t *testing.T) {
+	ctx := context.Background()
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("ListGroupsForUser", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+	assert.Error(t, removeUserFromGroups(ctx, iamMock, "user"))
+
+	iamMock.On("ListGroupsForUser", mock.Anything, mock.Anything).Return(&iam.ListGroupsForUserOutput{
+		Groups: []types.Group{{GroupName: aws.String("first")}, {GroupName: aws.String("second")}},
+	}, nil).Once()
+	iamMock.On("RemoveUserFromGroup", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("RemoveUserFromGroup", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+	assert.Error(t, removeUserFromGroups(ctx, iamMock, "user"))
+}
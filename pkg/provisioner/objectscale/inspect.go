@@ -0,0 +1,141 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+
+	"github.com/dell/cosi/pkg/provisioner/policy"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+const InspectTraceName = "Inspect"
+
+var _ driver.Inspector = (*Server)(nil)
+
+// ListBuckets returns names of the buckets in the namespace of the connection, sorted by name.
+func (s *Server) ListBuckets(ctx context.Context) ([]string, error) {
+	ctx, span := otel.Tracer(InspectTraceName).Start(ctx, "ObjectscaleListBuckets")
+	defer span.End()
+
+	buckets, err := s.mgmtClient.Buckets().List(ctx, map[string]string{"namespace": s.namespace})
+	if err != nil {
+		return nil, logAndTraceError(ctx, span, "failed listing buckets", err, codes.Internal)
+	}
+
+	names := make([]string, 0, len(buckets.Buckets))
+	for _, bucket := range buckets.Buckets {
+		names = append(names, bucket.Name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// GetBucketPolicy returns the decoded policy of the bucket. Empty document is returned, if the bucket has no policy.
+func (s *Server) GetBucketPolicy(ctx context.Context, bucketName string) (policy.Document, error) {
+	ctx, span := otel.Tracer(InspectTraceName).Start(ctx, "ObjectscaleGetBucketPolicy")
+	defer span.End()
+
+	parameters := map[string]string{"namespace": s.namespace}
+
+	bucketExists, err := checkBucketExistence(ctx, s, bucketName, parameters)
+	if err != nil {
+		return policy.Document{}, logAndTraceError(ctx, span, "failed checking if bucket exists", err, codes.Internal, "bucket", bucketName)
+	}
+
+	if !bucketExists {
+		return policy.Document{}, logAndTraceError(ctx, span, "bucket not found", nil, codes.NotFound, "bucket", bucketName)
+	}
+
+	existingPolicy, err := s.mgmtClient.Buckets().GetPolicy(ctx, bucketName, parameters)
+	if errors.Is(err, model.ErrParameterNotFound) || (err == nil && existingPolicy == "") {
+		return policy.Document{}, nil
+	}
+
+	if err != nil {
+		return policy.Document{}, logAndTraceError(ctx, span, "failed getting policy", err, codes.Internal, "bucket", bucketName)
+	}
+
+	document := policy.Document{}
+	if err := json.Unmarshal([]byte(existingPolicy), &document); err != nil {
+		return policy.Document{}, logAndTraceError(ctx, span, "failed unmarshalling policy", err, codes.Internal, "bucket", bucketName)
+	}
+
+	return document, nil
+}
+
+// ListUsers returns the IAM users managed by the connection, see managesUser, in all namespaces
+// in which users are created, with the metadata of their access keys.
+func (s *Server) ListUsers(ctx context.Context) ([]driver.User, error) {
+	ctx, span := otel.Tracer(InspectTraceName).Start(ctx, "ObjectscaleListUsers")
+	defer span.End()
+
+	users := []driver.User{}
+
+	for _, namespace := range s.userNamespaces() {
+		iamClient, err := s.iamClientFor(ctx, namespace)
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed getting IAM client", err, codes.Internal, "namespace", namespace)
+		}
+
+		err = s.forEachManagedUser(ctx, iamClient, namespace, func(iamUser types.User, tags []types.Tag) error {
+			user, err := describeUser(ctx, iamClient, namespace, aws.ToString(iamUser.UserName), tags)
+			if err != nil {
+				return fmt.Errorf("user %s: %w", aws.ToString(iamUser.UserName), err)
+			}
+
+			user.CreateDate = aws.ToTime(iamUser.CreateDate)
+			users = append(users, user)
+
+			return nil
+		})
+		if err != nil {
+			return nil, logAndTraceError(ctx, span, "failed listing users", err, codes.Internal, "namespace", namespace)
+		}
+	}
+
+	return users, nil
+}
+
+// describeUser returns the user with its tags and the metadata of its access keys.
+func describeUser(ctx context.Context, iamClient IAM, namespace, userName string, tags []types.Tag) (driver.User, error) {
+	user := driver.User{Namespace: namespace, Name: userName, Tags: map[string]string{}, AccessKeys: []driver.AccessKey{}}
+
+	for _, tag := range tags {
+		user.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	accessKeys, err := iamClient.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: &userName})
+	if err != nil {
+		return user, err
+	}
+
+	for _, accessKey := range accessKeys.AccessKeyMetadata {
+		user.AccessKeys = append(user.AccessKeys, driver.AccessKey{
+			ID:         aws.ToString(accessKey.AccessKeyId),
+			Status:     string(accessKey.Status),
+			CreateDate: aws.ToTime(accessKey.CreateDate),
+		})
+	}
+
+	return user, nil
+}
@@ -0,0 +1,221 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+// inspectedServer creates the server with mocked management API and IAM clients.
+func inspectedServer(t *testing.T) (*Server, *mocks.BucketServiceInterface, *omocks.IAM) {
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock).Maybe()
+
+	iamMock := omocks.NewIAM(t)
+
+	return &Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		clusterID:  testClusterID,
+		iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+	}, bucketsMock, iamMock
+}
+
+func TestListBuckets(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Success": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, bucketsMock, _ := inspectedServer(t)
+			bucketsMock.On("List", mock.Anything, map[string]string{"namespace": testNamespace}).Return(&model.BucketList{
+				Buckets: []model.Bucket{{Name: "b"}, {Name: "a"}},
+			}, nil).Once()
+
+			buckets, err := server.ListBuckets(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a", "b"}, buckets)
+		},
+		"Failure": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, bucketsMock, _ := inspectedServer(t)
+			bucketsMock.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			_, err := server.ListBuckets(ctx)
+			assert.Equal(t, codes.Internal, status.Code(err))
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestGetBucketPolicy(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Success": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, bucketsMock, _ := inspectedServer(t)
+			bucketsMock.On("Get", mock.Anything, "bucket", mock.Anything).Return(&model.Bucket{Name: "bucket"}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "bucket", mock.Anything).Return(
+				`{"Version":"2012-10-17","Statement":[{"Sid":"`+PolicySid+`","Effect":"Allow","Principal":{"AWS":"urn:osc:iam::ns:user/u"},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`,
+				nil).Once()
+
+			document, err := server.GetBucketPolicy(ctx, "bucket")
+			require.NoError(t, err)
+			assert.Equal(t, policy.Document{
+				Version: "2012-10-17",
+				Statement: []policy.StatementEntry{{
+					Sid:       PolicySid,
+					Effect:    allowEffect,
+					Principal: map[string]string{"AWS": "urn:osc:iam::ns:user/u"},
+					Action:    []string{"s3:GetObject"},
+					Resource:  []string{"arn:aws:s3:::bucket/*"},
+				}},
+			}, document)
+		},
+		"NoPolicy": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, bucketsMock, _ := inspectedServer(t)
+			bucketsMock.On("Get", mock.Anything, "bucket", mock.Anything).Return(&model.Bucket{Name: "bucket"}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "bucket", mock.Anything).Return("", model.ErrParameterNotFound).Once()
+
+			document, err := server.GetBucketPolicy(ctx, "bucket")
+			require.NoError(t, err)
+			assert.Equal(t, policy.Document{}, document)
+		},
+		"BucketNotFound": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, bucketsMock, _ := inspectedServer(t)
+			bucketsMock.On("Get", mock.Anything, "missing", mock.Anything).Return(nil, model.ErrParameterNotFound).Once()
+
+			_, err := server.GetBucketPolicy(ctx, "missing")
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		},
+		"InvalidPolicy": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, bucketsMock, _ := inspectedServer(t)
+			bucketsMock.On("Get", mock.Anything, "bucket", mock.Anything).Return(&model.Bucket{Name: "bucket"}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "bucket", mock.Anything).Return("{", nil).Once()
+
+			_, err := server.GetBucketPolicy(ctx, "bucket")
+			assert.Equal(t, codes.Internal, status.Code(err))
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Success": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, _, iamMock := inspectedServer(t)
+			iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/" + testClusterID + "/")}).Return(&iam.ListUsersOutput{
+				Users:       []types.User{{UserName: aws.String("first"), CreateDate: &created}},
+				IsTruncated: true,
+				Marker:      aws.String("next"),
+			}, nil).Once()
+			iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{Marker: aws.String("next"), PathPrefix: aws.String("/cosi/" + testClusterID + "/")}).Return(&iam.ListUsersOutput{
+				Users: []types.User{{UserName: aws.String("second"), CreateDate: &created}, {UserName: aws.String("foreign")}},
+			}, nil).Once()
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String("first")}).Return(&iam.ListUserTagsOutput{
+				Tags: []types.Tag{{Key: aws.String(bucketTagKey), Value: aws.String("bucket")}},
+			}, nil).Once()
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String("second")}).Return(&iam.ListUserTagsOutput{}, nil).Once()
+			// the user belongs to a different connection of the cluster, so it is not listed
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String("foreign")}).Return(&iam.ListUserTagsOutput{
+				Tags: []types.Tag{{Key: aws.String(connectionTagKey), Value: aws.String("other.id")}},
+			}, nil).Once()
+			iamMock.On("ListAccessKeys", mock.Anything, &iam.ListAccessKeysInput{UserName: aws.String("first")}).Return(&iam.ListAccessKeysOutput{
+				AccessKeyMetadata: []types.AccessKeyMetadata{{AccessKeyId: aws.String("key"), Status: types.StatusTypeActive, CreateDate: &created}},
+			}, nil).Once()
+			iamMock.On("ListAccessKeys", mock.Anything, &iam.ListAccessKeysInput{UserName: aws.String("second")}).Return(&iam.ListAccessKeysOutput{}, nil).Once()
+
+			users, err := server.ListUsers(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []driver.User{
+				{
+					Namespace:  testNamespace,
+					Name:       "first",
+					CreateDate: created,
+					Tags:       map[string]string{bucketTagKey: "bucket"},
+					AccessKeys: []driver.AccessKey{{ID: "key", Status: "Active", CreateDate: created}},
+				},
+				{
+					Namespace:  testNamespace,
+					Name:       "second",
+					CreateDate: created,
+					Tags:       map[string]string{},
+					AccessKeys: []driver.AccessKey{},
+				},
+			}, users)
+		},
+		"ListFailure": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, _, iamMock := inspectedServer(t)
+			iamMock.On("ListUsers", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			_, err := server.ListUsers(ctx)
+			assert.Equal(t, codes.Internal, status.Code(err))
+		},
+		"AccessKeysFailure": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server, _, iamMock := inspectedServer(t)
+			iamMock.On("ListUsers", mock.Anything, mock.Anything).Return(&iam.ListUsersOutput{
+				Users: []types.User{{UserName: aws.String("first")}},
+			}, nil).Once()
+			iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{}, nil).Once()
+			iamMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+
+			_, err := server.ListUsers(ctx)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	obsConfig "github.com/dell/cosi/pkg/config"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+// newLimits converts the limits from the configuration. Missing values are not limited.
+func newLimits(cfg *obsConfig.Limits) driver.Limits {
+	var l driver.Limits
+
+	if cfg == nil {
+		return l
+	}
+
+	if cfg.MaxInFlight != nil {
+		l.MaxInFlight = *cfg.MaxInFlight
+	}
+
+	if cfg.RequestsPerSecond != nil {
+		l.RequestsPerSecond = *cfg.RequestsPerSecond
+	}
+
+	if cfg.Burst != nil {
+		l.Burst = *cfg.Burst
+	}
+
+	return l
+}
+
+// Limits returns the limits of the driver operations, configured for the ObjectScale.
+func (s *Server) Limits() driver.Limits {
+	return s.limits
+}
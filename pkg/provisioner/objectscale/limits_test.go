@@ -0,0 +1,54 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+func TestNewLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *obsConfig.Limits
+		expected driver.Limits
+	}{
+		{
+			name:     "not configured",
+			cfg:      nil,
+			expected: driver.Limits{},
+		},
+		{
+			name:     "only max in-flight",
+			cfg:      &obsConfig.Limits{MaxInFlight: aws.Int(10)},
+			expected: driver.Limits{MaxInFlight: 10},
+		},
+		{
+			name: "all limits",
+			cfg: &obsConfig.Limits{
+				MaxInFlight:       aws.Int(10),
+				RequestsPerSecond: aws.Float64(2.5),
+				Burst:             aws.Int(5),
+			},
+			expected: driver.Limits{MaxInFlight: 10, RequestsPerSecond: 2.5, Burst: 5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{limits: newLimits(tc.cfg)}
+			assert.Equal(t, tc.expected, s.Limits())
+		})
+	}
+}
@@ -12,6 +12,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -55,6 +58,30 @@ type Server struct {
 	namespace   string
 	s3Endpoint  string
 	iamClient   func(context.Context) (IAM, error)
+	// policyTemplates are administrator supplied templates of bucket policy statements, indexed by name.
+	policyTemplates map[string]*policyTemplate
+	// allowedSourceCIDRs restrict source addresses from which the minted credentials can be used by default.
+	allowedSourceCIDRs []string
+	// accessManagement is the method of granting bucket access, either bucket policy or IAM groups.
+	accessManagement obsConfig.ObjectscaleAccessManagement
+	// crossNamespaceIAMClients are IAM clients for namespaces other than the connection namespace,
+	// in which users can be created for bucket access.
+	crossNamespaceIAMClients map[string]func(context.Context) (IAM, error)
+	// oidcProvider is trusted by the roles created for bucket access with IAM authentication.
+	// Nil if IAM authentication is not configured.
+	oidcProvider *oidcProvider
+	// usernameTemplate builds names of the IAM users. Nil if the default scheme is used.
+	usernameTemplate *template.Template
+	// clusterID identifies the cluster in the IAM path and tags of users and roles created by the driver.
+	clusterID string
+	// reconcileInterval is the interval of the periodic orphan detection. Zero if it runs only on demand.
+	reconcileInterval time.Duration
+	// removeOrphans enables removal of orphans found by the periodic reconciliation.
+	removeOrphans bool
+	// timeouts limit the driver operations. Zero timeout means only the deadline of the caller applies.
+	timeouts timeouts
+	// limits of the driver operations, enforced by the provisioner. Zero value means no limits.
+	limits driver.Limits
 	cosi.UnimplementedProvisionerServer
 }
 
@@ -62,15 +89,40 @@ type Server struct {
 //
 //go:generate go run github.com/vektra/mockery/v2@latest --all
 type IAM interface {
+	AddUserToGroup(ctx context.Context, params *iam.AddUserToGroupInput, optFns ...func(*iam.Options)) (*iam.AddUserToGroupOutput, error)
+	AttachGroupPolicy(ctx context.Context, params *iam.AttachGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.AttachGroupPolicyOutput, error)
 	CreateAccessKey(ctx context.Context, params *iam.CreateAccessKeyInput, optFns ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error)
+	CreateGroup(ctx context.Context, params *iam.CreateGroupInput, optFns ...func(*iam.Options)) (*iam.CreateGroupOutput, error)
+	CreatePolicy(ctx context.Context, params *iam.CreatePolicyInput, optFns ...func(*iam.Options)) (*iam.CreatePolicyOutput, error)
+	CreatePolicyVersion(ctx context.Context, params *iam.CreatePolicyVersionInput, optFns ...func(*iam.Options)) (*iam.CreatePolicyVersionOutput, error)
+	CreateRole(ctx context.Context, params *iam.CreateRoleInput, optFns ...func(*iam.Options)) (*iam.CreateRoleOutput, error)
 	CreateUser(ctx context.Context, params *iam.CreateUserInput, optFns ...func(*iam.Options)) (*iam.CreateUserOutput, error)
 	DeleteAccessKey(ctx context.Context, params *iam.DeleteAccessKeyInput, optFns ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error)
+	DeleteGroup(ctx context.Context, params *iam.DeleteGroupInput, optFns ...func(*iam.Options)) (*iam.DeleteGroupOutput, error)
+	DeletePolicy(ctx context.Context, params *iam.DeletePolicyInput, optFns ...func(*iam.Options)) (*iam.DeletePolicyOutput, error)
+	DeletePolicyVersion(ctx context.Context, params *iam.DeletePolicyVersionInput, optFns ...func(*iam.Options)) (*iam.DeletePolicyVersionOutput, error)
+	DeleteRole(ctx context.Context, params *iam.DeleteRoleInput, optFns ...func(*iam.Options)) (*iam.DeleteRoleOutput, error)
 	DeleteUser(ctx context.Context, params *iam.DeleteUserInput, optFns ...func(*iam.Options)) (*iam.DeleteUserOutput, error)
+	DetachGroupPolicy(ctx context.Context, params *iam.DetachGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.DetachGroupPolicyOutput, error)
+	GetGroup(ctx context.Context, params *iam.GetGroupInput, optFns ...func(*iam.Options)) (*iam.GetGroupOutput, error)
+	GetPolicy(ctx context.Context, params *iam.GetPolicyInput, optFns ...func(*iam.Options)) (*iam.GetPolicyOutput, error)
+	GetPolicyVersion(ctx context.Context, params *iam.GetPolicyVersionInput, optFns ...func(*iam.Options)) (*iam.GetPolicyVersionOutput, error)
+	GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error)
 	GetUser(ctx context.Context, params *iam.GetUserInput, optFns ...func(*iam.Options)) (*iam.GetUserOutput, error)
 	ListAccessKeys(ctx context.Context, params *iam.ListAccessKeysInput, optFns ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error)
+	ListGroupsForUser(ctx context.Context, params *iam.ListGroupsForUserInput, optFns ...func(*iam.Options)) (*iam.ListGroupsForUserOutput, error)
+	ListUserTags(ctx context.Context, params *iam.ListUserTagsInput, optFns ...func(*iam.Options)) (*iam.ListUserTagsOutput, error)
+	ListUsers(ctx context.Context, params *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error)
+	RemoveUserFromGroup(ctx context.Context, params *iam.RemoveUserFromGroupInput, optFns ...func(*iam.Options)) (*iam.RemoveUserFromGroupOutput, error)
+	TagUser(ctx context.Context, params *iam.TagUserInput, optFns ...func(*iam.Options)) (*iam.TagUserOutput, error)
 }
 
-var _ driver.Driver = (*Server)(nil)
+var (
+	_ driver.Driver    = (*Server)(nil)
+	_ driver.Prober    = (*Server)(nil)
+	_ driver.Limited   = (*Server)(nil)
+	_ driver.Describer = (*Server)(nil)
+)
 
 func New(objConfig *obsConfig.Objectscale) (*Server, error) {
 	log.Info("Initializing driver")
@@ -103,6 +155,48 @@ func New(objConfig *obsConfig.Objectscale) (*Server, error) {
 		return nil, errors.New("empty protocol S3 endpoint")
 	}
 
+	policyTemplates, err := newPolicyTemplates(objConfig.PolicyTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedSourceCIDRs, err := parseSourceCIDRs(objConfig.AllowedSourceCidrs)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcProvider, err := newOIDCProvider(objConfig.OidcProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID := id
+	if objConfig.ClusterId != nil {
+		clusterID = *objConfig.ClusterId
+	}
+
+	if err := validateClusterID(clusterID); err != nil {
+		return nil, err
+	}
+
+	reconcileInterval, removeOrphans, err := parseReconciliation(objConfig.Reconciliation)
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts, err := newTimeouts(objConfig.Timeouts)
+	if err != nil {
+		return nil, err
+	}
+
+	var usernameTemplate *template.Template
+	if objConfig.UsernameTemplate != nil {
+		usernameTemplate, err = newUsernameTemplate(*objConfig.UsernameTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	baseTransport, err := transport.New(objConfig.Tls)
 	if err != nil {
 		return nil, err
@@ -110,6 +204,7 @@ func New(objConfig *obsConfig.Objectscale) (*Server, error) {
 
 	httpClient := &http.Client{
 		Transport: baseTransport,
+		Timeout:   timeouts.httpRequest,
 	}
 
 	objectscaleAuthUser := client.AuthUser{
@@ -117,61 +212,111 @@ func New(objConfig *obsConfig.Objectscale) (*Server, error) {
 		Username: mgmtConfig.Username,
 		Password: mgmtConfig.Password,
 	}
+	session := newSession(&objectscaleAuthUser)
 
 	simpleClient := &client.Simple{
 		Endpoint:       mgmtConfig.EndpointURL,
-		Authenticator:  &objectscaleAuthUser,
+		Authenticator:  session,
 		OverrideHeader: false,
 		HTTPClient:     httpClient,
 	}
 
 	simpleClient.SetLogger(logger.Log())
 	clientset := rest.NewClientSet(simpleClient)
+	retryer := newRetryer()
 
 	log.Info("Driver has been successfully initialized")
 	iamFactory := &IAMClientFactory{
-		namespace:     *objConfig.Namespace,
-		client:        httpClient,
-		endpoint:      objConfig.MgmtEndpoint,
-		authenticator: &objectscaleAuthUser,
-		username:      mgmtConfig.Username,
-		password:      mgmtConfig.Password,
+		namespace: *objConfig.Namespace,
+		client:    httpClient,
+		endpoint:  objConfig.MgmtEndpoint,
+		session:   session,
+		username:  mgmtConfig.Username,
+		password:  mgmtConfig.Password,
+		retryer:   retryer,
+	}
+
+	crossNamespaceIAMClients, err := newCrossNamespaceIAMClients(iamFactory, objConfig.CrossNamespaces)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Server{
-		mgmtClient:  clientset,
+		mgmtClient:  &retryingClientSet{ClientSet: clientset, retryer: retryer},
 		backendID:   id,
 		emptyBucket: objConfig.EmptyBucket,
 		namespace:   *objConfig.Namespace,
 		s3Endpoint:  protocolS3Endpoint,
 		iamClient:   iamFactory.getIAMClient,
+
+		policyTemplates:    policyTemplates,
+		allowedSourceCIDRs: allowedSourceCIDRs,
+
+		accessManagement:         objConfig.AccessManagement,
+		crossNamespaceIAMClients: crossNamespaceIAMClients,
+		oidcProvider:             oidcProvider,
+		usernameTemplate:         usernameTemplate,
+		clusterID:                clusterID,
+		reconcileInterval:        reconcileInterval,
+		removeOrphans:            removeOrphans,
+		timeouts:                 timeouts,
+		limits:                   newLimits(objConfig.Limits),
 	}, nil
 }
 
 type IAMClientFactory struct {
-	namespace     string
-	username      string
-	password      string
-	endpoint      string
-	authenticator client.Authenticator
-	client        *http.Client
+	namespace string
+	username  string
+	password  string
+	endpoint  string
+	// session is shared by the factories of all namespaces and the management client.
+	session *session
+	client  *http.Client
+	// retryer retries the calls of the created IAM clients. Nil if the calls are not retried.
+	retryer *retryer
+
+	// mu guards iamClient, which is created on the first use.
+	mu        sync.Mutex
+	iamClient IAM
 }
 
-func (i IAMClientFactory) getIAMClient(ctx context.Context) (IAM, error) {
-	if !i.authenticator.IsAuthenticated() {
-		err := i.authenticator.Login(ctx, i.client)
-		if err != nil {
+// forNamespace creates factory of the IAM clients for another namespace, sharing the session.
+func (i *IAMClientFactory) forNamespace(namespace string) *IAMClientFactory {
+	return &IAMClientFactory{
+		namespace: namespace,
+		username:  i.username,
+		password:  i.password,
+		endpoint:  i.endpoint,
+		session:   i.session,
+		client:    i.client,
+		retryer:   i.retryer,
+	}
+}
+
+// getIAMClient logs in, if there is no session yet, and returns the IAM client. The client is created once
+// and reused, while the session token is set on every request, so the expired token is replaced transparently.
+func (i *IAMClientFactory) getIAMClient(ctx context.Context) (IAM, error) {
+	if !i.session.IsAuthenticated() {
+		if err := i.session.refresh(ctx, i.client, ""); err != nil {
 			return nil, err
 		}
 	}
 
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.iamClient != nil {
+		return i.iamClient, nil
+	}
+
 	iamConfig, err := config.LoadDefaultConfig(ctx,
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
 			i.username, i.password, "")),
 		config.WithHTTPClient(i.client),
+		config.WithRetryer(func() aws.Retryer { return aws.NopRetryer{} }),
 		config.WithAPIOptions([]func(*middleware.Stack) error{
 			smithyhttp.AddHeaderValue("X-Emc-Namespace", i.namespace),
-			smithyhttp.AddHeaderValue("X-Sds-Auth-Token", i.authenticator.Token()),
+			addSessionTokenMiddleware(i.session, i.client),
 		}),
 	)
 	if err != nil {
@@ -180,7 +325,19 @@ func (i IAMClientFactory) getIAMClient(ctx context.Context) (IAM, error) {
 	iamClient := iam.NewFromConfig(iamConfig, func(o *iam.Options) {
 		o.BaseEndpoint = aws.String(fmt.Sprintf("%s/iam/", i.endpoint))
 	})
-	return iamClient, nil
+
+	i.iamClient = iamClient
+	if i.retryer != nil {
+		i.iamClient = &retryingIAM{IAM: iamClient, retryer: i.retryer}
+	}
+
+	return i.iamClient, nil
+}
+
+// Probe lists the replication groups, to check if ObjectScale is available.
+func (s *Server) Probe(ctx context.Context) error {
+	_, err := s.mgmtClient.VPools().List(ctx)
+	return err
 }
 
 // ID extends COSI interface by adding ID method.
@@ -189,10 +346,5 @@ func (s *Server) ID() string {
 }
 
 func BuildUsername(namespace, access string) string {
-	raw := fmt.Sprintf("%v-user-%v", namespace, access)
-	if len(raw) > maxUsernameLength {
-		raw = raw[:maxUsernameLength]
-	}
-
-	return raw
+	return truncateName(fmt.Sprintf("%v-user-%v", namespace, access))
 }
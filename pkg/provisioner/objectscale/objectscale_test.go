@@ -13,7 +13,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/dell/cosi/pkg/config"
+	apimocks "github.com/dell/goobjectscale/pkg/client/api/mocks"
 	"github.com/dell/goobjectscale/pkg/client/rest/client/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -94,6 +96,186 @@ func TestNew(t *testing.T) {
 			wantErr:    true,
 			errMessage: "root certificate authority is missing",
 		},
+		{
+			name: "Error with invalid policy template",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				PolicyTemplates: []config.PolicyTemplate{
+					{Name: "broken", Template: "{{ .BucketName"},
+				},
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: "failed to parse policy template broken: template: broken:1: unclosed action",
+		},
+		{
+			name: "Error with invalid source CIDR",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				AllowedSourceCidrs: []string{"10.0.0.1"},
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: "invalid source CIDR 10.0.0.1: invalid CIDR address: 10.0.0.1",
+		},
+		{
+			name: "Error with duplicate cross namespace",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				CrossNamespaces: []string{"other", "other"},
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: "duplicate cross namespace other",
+		},
+		{
+			name: "Error with invalid OIDC issuer",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				OidcProvider: &config.OidcProvider{
+					Issuer: "http://oidc.example.com",
+				},
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: "invalid OIDC issuer http://oidc.example.com: expected https URL",
+		},
+		{
+			name: "Error with username template independent of access name",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				UsernameTemplate: aws.String("{{ .Namespace }}-user"),
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: "username template must depend on .AccessName",
+		},
+		{
+			name: "Error with invalid cluster id",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				ClusterId: aws.String("cluster/other"),
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: `invalid cluster id "cluster/other", expected up to 128 alphanumeric characters, '.', '_' or '-'`,
+		},
+		{
+			name: "Error with invalid reconciliation interval",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				Reconciliation: &config.Reconciliation{Interval: aws.String("0s")},
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: "invalid reconciliation interval 0s: must be positive",
+		},
+		{
+			name: "Error with invalid timeout",
+			config: &config.Objectscale{
+				Id: "test-id",
+				Credentials: config.Credentials{
+					Username: "test-username",
+					Password: testCred,
+				},
+				Namespace: &namespace,
+				Protocols: config.Protocols{
+					S3: &config.S3{
+						Endpoint: "s3.objectstore.test",
+					},
+				},
+				Timeouts: &config.Timeouts{HttpRequest: "fast"},
+				Tls: config.Tls{
+					Insecure: true,
+				},
+			},
+			wantErr:    true,
+			errMessage: `invalid httpRequest timeout fast: time: invalid duration "fast"`,
+		},
 		{
 			name: "Error when id is empty",
 			config: &config.Objectscale{
@@ -131,51 +313,49 @@ func TestNew(t *testing.T) {
 func TestGetIAMClient(t *testing.T) {
 	tests := []struct {
 		name             string
-		iamClientFactory func() IAMClientFactory
+		iamClientFactory func() *IAMClientFactory
 		expectError      bool
 	}{
 		{
 			name: "Get IAM client successfully when logged in",
-			iamClientFactory: func() IAMClientFactory {
+			iamClientFactory: func() *IAMClientFactory {
 				authenticator := mocks.NewAuthenticator(t)
 				authenticator.On("IsAuthenticated").Return(true)
-				authenticator.On("Token").Return("test-token")
-				return IAMClientFactory{
-					namespace:     "test-namespace",
-					username:      "test-unittest",
-					password:      "test-password",
-					authenticator: authenticator,
+				return &IAMClientFactory{
+					namespace: "test-namespace",
+					username:  "test-unittest",
+					password:  "test-password",
+					session:   newSession(authenticator),
 				}
 			},
 			expectError: false,
 		},
 		{
 			name: "Get IAM client successfully when not logged in",
-			iamClientFactory: func() IAMClientFactory {
+			iamClientFactory: func() *IAMClientFactory {
 				authenticator := mocks.NewAuthenticator(t)
 				authenticator.On("IsAuthenticated").Return(false)
 				authenticator.On("Login", mock.Anything, mock.Anything).Return(nil)
-				authenticator.On("Token").Return("test-token")
-				return IAMClientFactory{
-					namespace:     "test-namespace",
-					username:      "test-unittest",
-					password:      "test-password",
-					authenticator: authenticator,
+				return &IAMClientFactory{
+					namespace: "test-namespace",
+					username:  "test-unittest",
+					password:  "test-password",
+					session:   newSession(authenticator),
 				}
 			},
 			expectError: false,
 		},
 		{
 			name: "Failed to get IAM client due to login error",
-			iamClientFactory: func() IAMClientFactory {
+			iamClientFactory: func() *IAMClientFactory {
 				authenticator := mocks.NewAuthenticator(t)
 				authenticator.On("IsAuthenticated").Return(false)
 				authenticator.On("Login", mock.Anything, mock.Anything).Return(errors.New("login failure"))
-				return IAMClientFactory{
-					namespace:     "test-namespace",
-					username:      "test-unittest",
-					password:      "test-password",
-					authenticator: authenticator,
+				return &IAMClientFactory{
+					namespace: "test-namespace",
+					username:  "test-unittest",
+					password:  "test-password",
+					session:   newSession(authenticator),
 				}
 			},
 			expectError: true,
@@ -204,6 +384,20 @@ func TestID(t *testing.T) {
 	assert.Equal(t, s.ID(), "test-backend-id")
 }
 
+func TestProbe(t *testing.T) {
+	vpoolsMock := apimocks.NewVPoolServiceInterface(t)
+	vpoolsMock.On("List", mock.Anything).Return(nil, nil).Once()
+	vpoolsMock.On("List", mock.Anything).Return(nil, errors.New("unavailable")).Once()
+
+	mgmtClientMock := apimocks.NewClientSet(t)
+	mgmtClientMock.On("VPools").Return(vpoolsMock).Twice()
+
+	s := Server{mgmtClient: mgmtClientMock}
+
+	assert.NoError(t, s.Probe(context.Background()))
+	assert.Error(t, s.Probe(context.Background()))
+}
+
 func TestBuildUser(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -221,7 +415,7 @@ func TestBuildUser(t *testing.T) {
 			name:           "Success when 64 character limit is exceeded",
 			namespace:      "ns1",
 			bucketName:     "bucket123456789012345678901234567890123456789012345678901234567890",
-			expectedOutput: "ns1-user-bucket1234567890123456789012345678901234567890123456789",
+			expectedOutput: "ns1-user-bucket1234567890123456789012345678901234567890-ef14fdde",
 		},
 	}
 
@@ -0,0 +1,174 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/dell/cosi/pkg/version"
+)
+
+const (
+	// clusterTagKey is the IAM user and role tag storing ID of the cluster, which created it.
+	clusterTagKey = "cosi.dellemc.com/cluster"
+	// bucketTagKey is the IAM user and role tag storing name of the bucket, for which it was created.
+	bucketTagKey = "cosi.dellemc.com/bucket"
+	// versionTagKey is the IAM user and role tag storing version of the driver, which created it.
+	versionTagKey = "cosi.dellemc.com/version"
+	// connectionTagKey is the IAM user and role tag storing ID of the connection, which created it.
+	connectionTagKey = "cosi.dellemc.com/connection"
+
+	// pathPrefix is the prefix of IAM path of users and roles created by the driver.
+	pathPrefix = "/cosi/"
+)
+
+// errNotOwned is returned when IAM user or role belongs to a different cluster or bucket.
+var errNotOwned = errors.New("not owned by this driver")
+
+// clusterIDPattern limits cluster ID to characters allowed both in the IAM path and tag values.
+var clusterIDPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,128}$`)
+
+// validateClusterID checks if the cluster ID can be used in the IAM path and tags.
+func validateClusterID(clusterID string) error {
+	if !clusterIDPattern.MatchString(clusterID) {
+		return fmt.Errorf("invalid cluster id %q, expected up to 128 alphanumeric characters, '.', '_' or '-'", clusterID)
+	}
+
+	return nil
+}
+
+// iamPath returns IAM path of users and roles created by the driver, e.g. /cosi/<cluster>/.
+func (s *Server) iamPath() string {
+	return pathPrefix + s.clusterID + "/"
+}
+
+// ownershipTags returns tags recording the cluster, connection, bucket, BucketAccess and driver version,
+// for which the IAM user or role is created.
+func (s *Server) ownershipTags(bucketName, accessName string) []types.Tag {
+	return append(accessTags(accessName),
+		types.Tag{Key: aws.String(clusterTagKey), Value: aws.String(s.clusterID)},
+		types.Tag{Key: aws.String(connectionTagKey), Value: aws.String(s.backendID)},
+		types.Tag{Key: aws.String(bucketTagKey), Value: aws.String(bucketName)},
+		types.Tag{Key: aws.String(versionTagKey), Value: aws.String(version.Version)},
+	)
+}
+
+// checkOwnership verifies that the IAM user or role was created by this cluster for the bucket.
+// Missing tags are accepted, so entities created by older versions of the driver can still be deleted.
+func (s *Server) checkOwnership(tags []types.Tag, bucketName string) error {
+	for _, tag := range tags {
+		value := aws.ToString(tag.Value)
+
+		switch aws.ToString(tag.Key) {
+		case clusterTagKey:
+			if value != s.clusterID {
+				return fmt.Errorf("%w: created by cluster %s", errNotOwned, value)
+			}
+
+		case bucketTagKey:
+			if value != bucketName {
+				return fmt.Errorf("%w: created for bucket %s", errNotOwned, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// managesUser checks if the IAM user, listed under the IAM path of the cluster, is managed by this connection.
+// All connections of the cluster share the IAM path, and can create users in the same namespace using
+// crossNamespaces, so the users are told apart by the connection tag. Users without the tag, created by older
+// versions of the driver, are managed only in the namespace of the connection.
+func (s *Server) managesUser(namespace string, tags []types.Tag) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == connectionTagKey {
+			return aws.ToString(tag.Value) == s.backendID
+		}
+	}
+
+	return namespace == s.namespace
+}
+
+// forEachManagedUser calls fn with every IAM user in the namespace managed by this connection, and with its tags.
+// It is the only way the users of the connection are found, so all background and inspection operations
+// agree on which users belong to the connection. Iteration stops at the first error returned by fn.
+func (s *Server) forEachManagedUser(
+	ctx context.Context,
+	iamClient IAM,
+	namespace string,
+	fn func(user types.User, tags []types.Tag) error,
+) error {
+	var marker *string
+
+	for {
+		users, err := iamClient.ListUsers(ctx, &iam.ListUsersInput{Marker: marker, PathPrefix: aws.String(s.iamPath())})
+		if err != nil {
+			return fmt.Errorf("failed listing users: %w", err)
+		}
+
+		for _, user := range users.Users {
+			userName := aws.ToString(user.UserName)
+
+			tags, err := iamClient.ListUserTags(ctx, &iam.ListUserTagsInput{UserName: &userName})
+			if err != nil {
+				return fmt.Errorf("user %s: failed listing user tags: %w", userName, err)
+			}
+
+			if !s.managesUser(namespace, tags.Tags) {
+				continue
+			}
+
+			if err := fn(user, tags.Tags); err != nil {
+				return err
+			}
+		}
+
+		if !users.IsTruncated || users.Marker == nil {
+			return nil
+		}
+
+		marker = users.Marker
+	}
+}
+
+// checkUserOwnership verifies that the IAM user was created by this cluster for the bucket, before it is deleted.
+func (s *Server) checkUserOwnership(ctx context.Context, iamClient IAM, userName, bucketName string) error {
+	tags, err := iamClient.ListUserTags(ctx, &iam.ListUserTagsInput{UserName: &userName})
+	if err != nil {
+		return fmt.Errorf("failed listing user tags: %w", err)
+	}
+
+	return s.checkOwnership(tags.Tags, bucketName)
+}
+
+// checkRoleOwnership verifies that the IAM role was created by this cluster for the bucket, before it is deleted.
+// False is returned if the role does not exist.
+func (s *Server) checkRoleOwnership(ctx context.Context, iamClient IAM, roleName, bucketName string) (bool, error) {
+	role, err := iamClient.GetRole(ctx, &iam.GetRoleInput{RoleName: &roleName})
+	if isNoSuchEntity(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed getting role: %w", err)
+	}
+
+	if role.Role == nil {
+		return true, nil
+	}
+
+	return true, s.checkOwnership(role.Role.Tags, bucketName)
+}
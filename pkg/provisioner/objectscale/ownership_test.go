@@ -0,0 +1,100 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+	"github.com/dell/cosi/pkg/version"
+)
+
+const testClusterID = "cluster"
+
+func TestValidateClusterID(t *testing.T) {
+	for _, clusterID := range []string{"cluster", "prod-eu.1_a"} {
+		assert.NoError(t, validateClusterID(clusterID), clusterID)
+	}
+
+	for _, clusterID := range []string{"", "cluster/other", "cluster id"} {
+		assert.Error(t, validateClusterID(clusterID), clusterID)
+	}
+}
+
+func TestOwnershipTags(t *testing.T) {
+	server := Server{clusterID: testClusterID, backendID: testID}
+
+	assert.Equal(t, "/cosi/cluster/", server.iamPath())
+	assert.ElementsMatch(t, []types.Tag{
+		{Key: aws.String(accessTagKey), Value: aws.String("access")},
+		{Key: aws.String(clusterTagKey), Value: aws.String(testClusterID)},
+		{Key: aws.String(connectionTagKey), Value: aws.String(testID)},
+		{Key: aws.String(bucketTagKey), Value: aws.String(testBucketName)},
+		{Key: aws.String(versionTagKey), Value: aws.String(version.Version)},
+	}, server.ownershipTags(testBucketName, "access"))
+}
+
+func TestManagesUser(t *testing.T) {
+	server := Server{namespace: testNamespace, backendID: testID}
+
+	assert.True(t, server.managesUser(testNamespace, nil))
+	assert.False(t, server.managesUser("other-namespace", nil))
+	assert.True(t, server.managesUser("other-namespace", []types.Tag{
+		{Key: aws.String(connectionTagKey), Value: aws.String(testID)},
+	}))
+	assert.False(t, server.managesUser(testNamespace, []types.Tag{
+		{Key: aws.String(connectionTagKey), Value: aws.String("other.id")},
+	}))
+}
+
+func TestCheckOwnership(t *testing.T) {
+	server := Server{clusterID: testClusterID}
+
+	assert.NoError(t, server.checkOwnership(nil, testBucketName))
+	assert.NoError(t, server.checkOwnership(server.ownershipTags(testBucketName, "access"), testBucketName))
+
+	err := server.checkOwnership((&Server{clusterID: "other"}).ownershipTags(testBucketName, "access"), testBucketName)
+	assert.True(t, errors.Is(err, errNotOwned))
+
+	err = server.checkOwnership(server.ownershipTags("other-bucket", "access"), testBucketName)
+	assert.True(t, errors.Is(err, errNotOwned))
+}
+
+func TestCheckRoleOwnership(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	server := Server{clusterID: testClusterID}
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetRole", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+	iamMock.On("GetRole", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+	iamMock.On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+		Role: &types.Role{Tags: (&Server{clusterID: "other"}).ownershipTags(testBucketName, "access")},
+	}, nil).Once()
+
+	exists, err := server.checkRoleOwnership(ctx, iamMock, "role", testBucketName)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = server.checkRoleOwnership(ctx, iamMock, "role", testBucketName)
+	assert.Error(t, err)
+
+	exists, err = server.checkRoleOwnership(ctx, iamMock, "role", testBucketName)
+	assert.True(t, errors.Is(err, errNotOwned))
+	assert.True(t, exists)
+}
@@ -0,0 +1,228 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"go.opentelemetry.io/otel"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+const (
+	// PolicyTemplateParam is the BucketAccessClass parameter selecting the policy template used for the access.
+	PolicyTemplateParam = "policyTemplate"
+
+	// putBucketPolicyAction is refused in rendered statements, unless template explicitly allows it.
+	putBucketPolicyAction = "s3:PutBucketPolicy"
+)
+
+// PolicyTemplateData holds variables available to the policy templates.
+type PolicyTemplateData struct {
+	// BucketName is the name of the bucket the access is granted to.
+	BucketName string
+	// PrincipalARN is the principal of the user the access is granted for.
+	PrincipalARN string
+	// Namespace is the ObjectScale namespace of the bucket.
+	Namespace string
+	// AccessName is the name of the BucketAccess.
+	AccessName string
+}
+
+// policyTemplateSampleData is used to render the policy templates, when they are loaded,
+// so invalid templates are refused before they are used for any access.
+var policyTemplateSampleData = PolicyTemplateData{
+	BucketName:   "bucket",
+	PrincipalARN: BuildPrincipalString("user", "namespace"),
+	Namespace:    "namespace",
+	AccessName:   "access",
+}
+
+// policyTemplate is a parsed administrator supplied policy template.
+type policyTemplate struct {
+	tmpl                 *template.Template
+	allowPutBucketPolicy bool
+}
+
+// newPolicyTemplates parses policy templates from the configuration and indexes them by name.
+func newPolicyTemplates(cfg []obsConfig.PolicyTemplate) (map[string]*policyTemplate, error) {
+	templates := make(map[string]*policyTemplate, len(cfg))
+
+	for _, t := range cfg {
+		if t.Name == "" {
+			return nil, errors.New("empty policy template name")
+		}
+
+		if _, ok := templates[t.Name]; ok {
+			return nil, fmt.Errorf("duplicate policy template %s", t.Name)
+		}
+
+		tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse policy template %s: %w", t.Name, err)
+		}
+
+		pt := &policyTemplate{
+			tmpl:                 tmpl,
+			allowPutBucketPolicy: t.AllowPutBucketPolicy,
+		}
+
+		if _, err := pt.render(policyTemplateSampleData); err != nil {
+			return nil, err
+		}
+
+		templates[t.Name] = pt
+	}
+
+	return templates, nil
+}
+
+// renderPolicyTemplate renders the named policy template into the validated list of statements.
+// Sid and Principal of every statement are always set by the driver, so the statements can be found
+// and removed when the access is revoked.
+func (s *Server) renderPolicyTemplate(ctx context.Context, name string, data PolicyTemplateData) ([]policy.StatementEntry, error) {
+	_, span := otel.Tracer(GrantBucketAccessTraceName).Start(ctx, "ObjectscaleRenderPolicyTemplate")
+	defer span.End()
+
+	t, ok := s.policyTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("policy template %s is not configured", name)
+	}
+
+	return t.render(data)
+}
+
+// render renders the template into the validated list of statements, granting access only to the bucket
+// and its objects. Sid and Principal of every statement are set to the driver ones.
+func (t *policyTemplate) render(data PolicyTemplateData) ([]policy.StatementEntry, error) {
+	name := t.tmpl.Name()
+
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render policy template %s: %w", name, err)
+	}
+
+	statements, err := decodeStatements(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("policy template %s rendered invalid JSON: %w", name, err)
+	}
+
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("policy template %s rendered no statements", name)
+	}
+
+	for i := range statements {
+		if err := statements[i].Validate(); err != nil {
+			return nil, fmt.Errorf("policy template %s rendered invalid statement: %w", name, err)
+		}
+
+		if err := checkResources(statements[i], data.BucketName); err != nil {
+			return nil, fmt.Errorf("policy template %s rendered invalid statement: %w", name, err)
+		}
+
+		// malformed action is refused, as it cannot be decided whether it grants the action
+		grants, err := statements[i].GrantsAction(putBucketPolicyAction)
+		if err != nil {
+			return nil, fmt.Errorf("policy template %s rendered invalid statement: %w", name, err)
+		}
+
+		if grants && !t.allowPutBucketPolicy {
+			return nil, fmt.Errorf("policy template %s grants %s, which is not allowed", name, putBucketPolicyAction)
+		}
+
+		statements[i].Sid = PolicySid
+		statements[i].Principal = map[string]string{"AWS": data.PrincipalARN}
+	}
+
+	return statements, nil
+}
+
+// checkResources checks that the statement grants access only to the bucket and its objects.
+func checkResources(statement policy.StatementEntry, bucketName string) error {
+	bucketARN := fmt.Sprintf("arn:aws:s3:::%s", bucketName)
+
+	for _, resource := range statement.Resource {
+		if resource != bucketARN && !strings.HasPrefix(resource, bucketARN+"/") {
+			return fmt.Errorf("resource %s is outside of the bucket %s", resource, bucketName)
+		}
+	}
+
+	return nil
+}
+
+// decodeStatements decodes either a single JSON statement or JSON array of statements.
+// Unknown fields, e.g. NotAction or misspelled Condition, are refused, as they would be silently dropped.
+func decodeStatements(b []byte) ([]policy.StatementEntry, error) {
+	b = bytes.TrimSpace(b)
+
+	if bytes.HasPrefix(b, []byte("[")) {
+		var statements []policy.StatementEntry
+		if err := decodeStrict(b, &statements); err != nil {
+			return nil, err
+		}
+
+		return statements, nil
+	}
+
+	var statement policy.StatementEntry
+	if err := decodeStrict(b, &statement); err != nil {
+		return nil, err
+	}
+
+	return []policy.StatementEntry{statement}, nil
+}
+
+// decodeStrict decodes the single JSON value, refusing unknown fields and trailing data.
+func decodeStrict(b []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("unexpected data after JSON value")
+	}
+
+	return nil
+}
+
+// mergePolicyStatements replaces all statements previously created by the driver for the principal
+// with the statements rendered from the policy template.
+func mergePolicyStatements(
+	ctx context.Context,
+	inputStatements []policy.StatementEntry,
+	newStatements []policy.StatementEntry,
+	awsPrincipalString string,
+) []policy.StatementEntry {
+	_, span := otel.Tracer(GrantBucketAccessTraceName).Start(ctx, "ObjectscaleMergePolicyStatements")
+	defer span.End()
+
+	merged := make([]policy.StatementEntry, 0, len(inputStatements)+len(newStatements))
+
+	for _, statement := range inputStatements {
+		if statement.Sid == PolicySid && statement.Principal["AWS"] == awsPrincipalString {
+			continue
+		}
+
+		merged = append(merged, statement)
+	}
+
+	return append(merged, newStatements...)
+}
@@ -0,0 +1,301 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+const (
+	testReadOnlyTemplate = `{
+		"Effect": "Allow",
+		"Action": ["s3:GetObject", "s3:ListBucket"],
+		"Resource": ["arn:aws:s3:::{{ .BucketName }}", "arn:aws:s3:::{{ .BucketName }}/{{ .AccessName }}/*"]
+	}`
+	testPolicyManagementTemplate = `[{
+		"Effect": "Allow",
+		"Action": ["s3:*"],
+		"Resource": ["arn:aws:s3:::{{ .BucketName }}"]
+	}]`
+)
+
+func TestNewPolicyTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  []config.PolicyTemplate
+		wantErr bool
+	}{
+		{
+			name:    "no templates",
+			config:  nil,
+			wantErr: false,
+		},
+		{
+			name: "valid templates",
+			config: []config.PolicyTemplate{
+				{Name: "read-only", Template: testReadOnlyTemplate},
+				{Name: "admin", Template: testPolicyManagementTemplate, AllowPutBucketPolicy: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty name",
+			config: []config.PolicyTemplate{
+				{Name: "", Template: testReadOnlyTemplate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			config: []config.PolicyTemplate{
+				{Name: "read-only", Template: testReadOnlyTemplate},
+				{Name: "read-only", Template: testReadOnlyTemplate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid template syntax",
+			config: []config.PolicyTemplate{
+				{Name: "broken", Template: `{"Effect": "{{ .Effect"}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid JSON",
+			config: []config.PolicyTemplate{
+				{Name: "invalid-json", Template: `{"Effect": "Allow",`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "trailing data",
+			config: []config.PolicyTemplate{
+				{Name: "trailing", Template: testReadOnlyTemplate + `{}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported NotAction",
+			config: []config.PolicyTemplate{
+				{Name: "not-action", Template: `{"Effect": "Allow", "Action": ["s3:GetObject"], "NotAction": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::{{ .BucketName }}"]}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "misspelled condition",
+			config: []config.PolicyTemplate{
+				{Name: "conditon", Template: `{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::{{ .BucketName }}/*"], "Conditon": {"Bool": {"aws:SecureTransport": "true"}}}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource of all buckets",
+			config: []config.PolicyTemplate{
+				{Name: "all-buckets", Template: `{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"]}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource of other bucket",
+			config: []config.PolicyTemplate{
+				{Name: "other-bucket", Template: `{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::{{ .BucketName }}-other/*"]}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed action",
+			config: []config.PolicyTemplate{
+				{Name: "malformed", Template: `{"Effect": "Allow", "Action": ["s3:[Get*"], "Resource": ["arn:aws:s3:::{{ .BucketName }}"]}`},
+			},
+			wantErr: true,
+		},
+		{
+			name: "put bucket policy not allowed",
+			config: []config.PolicyTemplate{
+				{Name: "policy-management", Template: testPolicyManagementTemplate},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := newPolicyTemplates(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, templates, len(tt.config))
+		})
+	}
+}
+
+// parsePolicyTemplates parses the policy templates without rendering them, as newPolicyTemplates does,
+// so the templates failing to render can be used in the tests.
+func parsePolicyTemplates(t *testing.T, cfg ...config.PolicyTemplate) map[string]*policyTemplate {
+	templates := make(map[string]*policyTemplate, len(cfg))
+
+	for _, c := range cfg {
+		tmpl, err := template.New(c.Name).Option("missingkey=error").Parse(c.Template)
+		require.NoError(t, err)
+
+		templates[c.Name] = &policyTemplate{tmpl: tmpl, allowPutBucketPolicy: c.AllowPutBucketPolicy}
+	}
+
+	return templates
+}
+
+func TestRenderPolicyTemplate(t *testing.T) {
+	templates := parsePolicyTemplates(t,
+		config.PolicyTemplate{Name: "read-only", Template: testReadOnlyTemplate},
+		config.PolicyTemplate{Name: "policy-management", Template: testPolicyManagementTemplate},
+		config.PolicyTemplate{Name: "policy-management-allowed", Template: testPolicyManagementTemplate, AllowPutBucketPolicy: true},
+		config.PolicyTemplate{Name: "invalid-json", Template: `{"Effect": "Allow",`},
+		config.PolicyTemplate{Name: "invalid-statement", Template: `{"Effect": "Allow", "Action": ["s3:GetObject"]}`},
+		config.PolicyTemplate{Name: "empty", Template: `[]`},
+		config.PolicyTemplate{Name: "missing-variable", Template: `{{ .Missing }}`},
+		config.PolicyTemplate{
+			Name:     "fixed-bucket",
+			Template: `{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::bucket/*"]}`,
+		},
+		config.PolicyTemplate{
+			Name:     "malformed-action",
+			Template: `{"Effect": "Allow", "Action": ["s3:[Put*"], "Resource": ["arn:aws:s3:::{{ .BucketName }}"]}`,
+		},
+	)
+
+	server := Server{policyTemplates: templates}
+	data := PolicyTemplateData{
+		BucketName:   testBucketName,
+		PrincipalARN: BuildPrincipalString("user", testNamespace),
+		Namespace:    testNamespace,
+		AccessName:   "access",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected []policy.StatementEntry
+		wantErr  bool
+	}{
+		{
+			name:     "single statement",
+			template: "read-only",
+			expected: []policy.StatementEntry{
+				{
+					Sid:       PolicySid,
+					Effect:    policy.EffectAllow,
+					Action:    []string{"s3:GetObject", "s3:ListBucket"},
+					Resource:  []string{"arn:aws:s3:::test_bucket", "arn:aws:s3:::test_bucket/access/*"},
+					Principal: map[string]string{"AWS": data.PrincipalARN},
+				},
+			},
+		},
+		{
+			name:     "put bucket policy allowed",
+			template: "policy-management-allowed",
+			expected: []policy.StatementEntry{
+				{
+					Sid:       PolicySid,
+					Effect:    policy.EffectAllow,
+					Action:    []string{"s3:*"},
+					Resource:  []string{"arn:aws:s3:::test_bucket"},
+					Principal: map[string]string{"AWS": data.PrincipalARN},
+				},
+			},
+		},
+		{
+			name:     "put bucket policy not allowed",
+			template: "policy-management",
+			wantErr:  true,
+		},
+		{
+			name:     "not configured",
+			template: "unknown",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid JSON",
+			template: "invalid-json",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid statement",
+			template: "invalid-statement",
+			wantErr:  true,
+		},
+		{
+			name:     "no statements",
+			template: "empty",
+			wantErr:  true,
+		},
+		{
+			name:     "missing variable",
+			template: "missing-variable",
+			wantErr:  true,
+		},
+		{
+			name:     "resource outside of the bucket",
+			template: "fixed-bucket",
+			wantErr:  true,
+		},
+		{
+			name:     "malformed action",
+			template: "malformed-action",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := server.renderPolicyTemplate(context.Background(), tt.template, data)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, statements)
+		})
+	}
+}
+
+func TestMergePolicyStatements(t *testing.T) {
+	principal := BuildPrincipalString("user", testNamespace)
+	other := BuildPrincipalString("other", testNamespace)
+
+	existing := []policy.StatementEntry{
+		{Sid: PolicySid, Effect: policy.EffectAllow, Action: []string{"*"}, Principal: map[string]string{"AWS": principal}},
+		{Sid: PolicySid, Effect: policy.EffectAllow, Action: []string{"*"}, Principal: map[string]string{"AWS": other}},
+		{Sid: "custom", Effect: policy.EffectAllow, Action: []string{"*"}, Principal: map[string]string{"AWS": principal}},
+	}
+	rendered := []policy.StatementEntry{
+		{Sid: PolicySid, Effect: policy.EffectAllow, Action: []string{"s3:GetObject"}, Principal: map[string]string{"AWS": principal}},
+	}
+
+	merged := mergePolicyStatements(context.Background(), existing, rendered, principal)
+
+	assert.Equal(t, []policy.StatementEntry{existing[1], existing[2], rendered[0]}, merged)
+}
+
+// grantsAction checks if the statement grants the action, failing on malformed actions.
+func grantsAction(statement policy.StatementEntry, action string) bool {
+	grants, err := statement.GrantsAction(action)
+	return err == nil && grants
+}
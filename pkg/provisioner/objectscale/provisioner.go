@@ -10,7 +10,6 @@ package objectscale
 
 import (
 	"context"
-	"time"
 
 	"github.com/dell/cosi/pkg/provisioner/policy"
 
@@ -21,15 +20,13 @@ import (
 	cosi "sigs.k8s.io/container-object-storage-interface/proto"
 )
 
-const (
-	defaultTimeout = time.Second * 20
-)
-
 func parsePolicyStatement(
 	ctx context.Context,
 	inputStatements []policy.StatementEntry,
 	awsBucketResourceARNs []string,
 	awsPrincipalString string,
+	actions []string,
+	sourceCIDRs []string,
 ) []policy.StatementEntry {
 	_, span := otel.Tracer(GrantBucketAccessTraceName).Start(ctx, "ObjectscaleParsePolicyStatement")
 	defer span.End()
@@ -46,7 +43,10 @@ func parsePolicyStatement(
 	newStatement.Resource = awsBucketResourceARNs
 	newStatement.Effect = allowEffect
 	newStatement.Principal = map[string]string{"AWS": awsPrincipalString}
-	newStatement.Action = []string{"*"}
+	newStatement.Action = actions
+	if len(sourceCIDRs) > 0 {
+		newStatement.AddCondition(sourceIPConditionOperator, sourceIPConditionKey, sourceCIDRs...)
+	}
 	inputStatements = append(inputStatements, newStatement)
 
 	return inputStatements
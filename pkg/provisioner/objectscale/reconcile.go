@@ -0,0 +1,317 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+const (
+	// orphanGracePeriod protects users created by a grant, which is still in progress, from being reported as orphans.
+	orphanGracePeriod = time.Hour
+
+	orphanKindUser      = "user"
+	orphanKindStatement = "statement"
+
+	ReconcileTraceName = "Reconcile"
+)
+
+// orphansGauge reports the number of orphans found by the last reconciliation.
+var orphansGauge, _ = otel.Meter(ReconcileTraceName).Int64Gauge("cosi_orphans",
+	metric.WithDescription("Number of orphaned IAM users and bucket policy statements found by the last reconciliation"))
+
+var _ driver.Reconciler = (*Server)(nil)
+
+// parseReconciliation returns interval of the periodic reconciliation and whether the orphans are removed.
+// Zero interval is returned if the reconciliation runs only on demand.
+func parseReconciliation(cfg *obsConfig.Reconciliation) (time.Duration, bool, error) {
+	if cfg == nil {
+		return 0, false, nil
+	}
+
+	raw := strings.TrimSpace(aws.ToString(cfg.Interval))
+	if raw == "" {
+		return 0, cfg.RemoveOrphans, nil
+	}
+
+	interval, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid reconciliation interval %s: %w", raw, err)
+	}
+
+	if interval <= 0 {
+		return 0, false, fmt.Errorf("invalid reconciliation interval %s: must be positive", raw)
+	}
+
+	return interval, cfg.RemoveOrphans, nil
+}
+
+// Reconcile finds IAM users created by the driver, which are not used by any bucket access,
+// and bucket policy statements added by the driver for principals, which no longer exist.
+// The orphans are removed only if remove is true and no error occurred, otherwise they are only reported.
+// Only users managed by the connection are considered, see managesUser. Users created by versions of the driver,
+// which did not place them in the IAM path of the cluster, are ignored.
+func (s *Server) Reconcile(ctx context.Context, remove bool) (*driver.OrphanReport, error) {
+	ctx, span := otel.Tracer(ReconcileTraceName).Start(ctx, "ObjectscaleReconcile")
+	defer span.End()
+
+	parameters := map[string]string{"namespace": s.namespace}
+
+	buckets, err := s.mgmtClient.Buckets().List(ctx, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing buckets: %w", err)
+	}
+
+	report := &driver.OrphanReport{Removed: remove}
+	referenced := map[string]bool{}
+
+	var errs []error
+
+	for _, bucket := range buckets.Buckets {
+		principals, err := s.bucketPrincipals(ctx, bucket.Name, parameters)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bucket %s: %w", bucket.Name, err))
+			continue
+		}
+
+		for _, principal := range principals {
+			referenced[principal] = true
+
+			exists, err := s.principalExists(ctx, principal)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("bucket %s: principal %s: %w", bucket.Name, principal, err))
+				continue
+			}
+
+			if !exists {
+				report.Statements = append(report.Statements, driver.OrphanStatement{Bucket: bucket.Name, Principal: principal})
+			}
+		}
+	}
+
+	now := time.Now()
+
+	for _, namespace := range s.userNamespaces() {
+		users, err := s.orphanUsers(ctx, namespace, referenced, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", namespace, err))
+		}
+
+		report.Users = append(report.Users, users...)
+	}
+
+	switch {
+	case remove && len(errs) > 0:
+		// Principals of the buckets, which policies failed to be read, are unknown,
+		// so users still in use could be reported as orphans.
+		report.Removed = false
+		errs = append(errs, errors.New("orphans are not removed, as the reconciliation is incomplete"))
+
+	case remove:
+		errs = append(errs, s.deleteOrphans(ctx, report, parameters))
+	}
+
+	s.logOrphans(ctx, report)
+
+	return report, errors.Join(errs...)
+}
+
+// bucketPrincipals returns principals of the statements added by the driver to the bucket policy.
+func (s *Server) bucketPrincipals(ctx context.Context, bucketName string, parameters map[string]string) ([]string, error) {
+	existingPolicy, err := s.mgmtClient.Buckets().GetPolicy(ctx, bucketName, parameters)
+	if errors.Is(err, model.ErrParameterNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed getting policy: %w", err)
+	}
+
+	if existingPolicy == "" {
+		return nil, nil
+	}
+
+	document := policy.Document{}
+	if err := json.Unmarshal([]byte(existingPolicy), &document); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling policy: %w", err)
+	}
+
+	var principals []string
+
+	for _, statement := range document.Statement {
+		if statement.Sid == PolicySid && statement.Principal["AWS"] != "" {
+			principals = append(principals, statement.Principal["AWS"])
+		}
+	}
+
+	return principals, nil
+}
+
+// principalExists checks if the user or role of the principal exists.
+// Principals in namespaces not managed by the driver are assumed to exist.
+func (s *Server) principalExists(ctx context.Context, principal string) (bool, error) {
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(principal, principalPrefix), ":")
+	if !ok || !strings.HasPrefix(principal, principalPrefix) || !s.isUserNamespace(namespace) {
+		return true, nil
+	}
+
+	iamClient, err := s.iamClientFor(ctx, namespace)
+	if err != nil {
+		return false, fmt.Errorf("failed getting IAM client: %w", err)
+	}
+
+	if userName, ok := strings.CutPrefix(name, "user/"); ok {
+		return checkUserExistence(ctx, iamClient, userName)
+	}
+
+	if roleName, ok := strings.CutPrefix(name, "role/"); ok && namespace == s.namespace {
+		_, err := iamClient.GetRole(ctx, &iam.GetRoleInput{RoleName: &roleName})
+		if isNoSuchEntity(err) {
+			return false, nil
+		}
+
+		return err == nil, err
+	}
+
+	return true, nil
+}
+
+// orphanUsers returns users created by the driver in the namespace, which are neither referenced by a bucket policy,
+// nor members of a bucket group.
+func (s *Server) orphanUsers(
+	ctx context.Context,
+	namespace string,
+	referenced map[string]bool,
+	now time.Time,
+) ([]driver.OrphanUser, error) {
+	iamClient, err := s.iamClientFor(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting IAM client: %w", err)
+	}
+
+	var orphans []driver.OrphanUser
+
+	err = s.forEachManagedUser(ctx, iamClient, namespace, func(user types.User, _ []types.Tag) error {
+		userName := aws.ToString(user.UserName)
+		if referenced[BuildPrincipalString(userName, namespace)] {
+			return nil
+		}
+
+		if user.CreateDate != nil && now.Sub(*user.CreateDate) < orphanGracePeriod {
+			return nil
+		}
+
+		grouped, err := isBucketGroupMember(ctx, iamClient, userName)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", userName, err)
+		}
+
+		if !grouped {
+			orphans = append(orphans, driver.OrphanUser{Namespace: namespace, Name: userName})
+		}
+
+		return nil
+	})
+
+	return orphans, err
+}
+
+// isBucketGroupMember checks if the user is a member of any group created for a bucket.
+func isBucketGroupMember(ctx context.Context, iamClient IAM, userName string) (bool, error) {
+	groups, err := iamClient.ListGroupsForUser(ctx, &iam.ListGroupsForUserInput{UserName: &userName})
+	if err != nil {
+		return false, fmt.Errorf("failed listing groups: %w", err)
+	}
+
+	for _, group := range groups.Groups {
+		if strings.HasPrefix(aws.ToString(group.GroupName), groupPrefix) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// deleteOrphans deletes the orphaned users and removes the orphaned statements from the bucket policies.
+func (s *Server) deleteOrphans(ctx context.Context, report *driver.OrphanReport, parameters map[string]string) error {
+	var errs []error
+
+	for _, user := range report.Users {
+		iamClient, err := s.iamClientFor(ctx, user.Namespace)
+		if err == nil {
+			err = deleteUser(ctx, iamClient, user.Name)
+		}
+
+		if err != nil && !isNoSuchEntity(err) {
+			errs = append(errs, fmt.Errorf("failed deleting user %s in namespace %s: %w", user.Name, user.Namespace, err))
+		}
+	}
+
+	for _, statement := range report.Statements {
+		if err := removeBucketPolicy(ctx, s, statement.Bucket, statement.Principal, parameters); err != nil {
+			errs = append(errs, fmt.Errorf("failed removing statement of %s from bucket %s: %w", statement.Principal, statement.Bucket, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// logOrphans logs every orphan and the summary, and records the orphan counts.
+func (s *Server) logOrphans(ctx context.Context, report *driver.OrphanReport) {
+	action := "Found"
+	if report.Removed {
+		action = "Removed"
+	}
+
+	for _, user := range report.Users {
+		log.WithFields(csmlog.Fields{
+			"id":        s.backendID,
+			"namespace": user.Namespace,
+			"user":      user.Name,
+		}).Warnf("%s orphaned IAM user", action)
+	}
+
+	for _, statement := range report.Statements {
+		log.WithFields(csmlog.Fields{
+			"id":        s.backendID,
+			"bucket":    statement.Bucket,
+			"principal": statement.Principal,
+		}).Warnf("%s orphaned bucket policy statement", action)
+	}
+
+	log.WithFields(csmlog.Fields{
+		"id":         s.backendID,
+		"users":      len(report.Users),
+		"statements": len(report.Statements),
+		"removed":    report.Removed,
+	}).Info("Reconciliation finished")
+
+	orphansGauge.Record(ctx, int64(len(report.Users)),
+		metric.WithAttributes(attribute.String("id", s.backendID), attribute.String("kind", orphanKindUser)))
+	orphansGauge.Record(ctx, int64(len(report.Statements)),
+		metric.WithAttributes(attribute.String("id", s.backendID), attribute.String("kind", orphanKindStatement)))
+}
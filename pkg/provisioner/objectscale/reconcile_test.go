@@ -0,0 +1,314 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+)
+
+func TestParseReconciliation(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *obsConfig.Reconciliation
+		interval time.Duration
+		remove   bool
+		wantErr  bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:   "on demand",
+			cfg:    &obsConfig.Reconciliation{RemoveOrphans: true},
+			remove: true,
+		},
+		{
+			name:     "periodic",
+			cfg:      &obsConfig.Reconciliation{Interval: aws.String("1h")},
+			interval: time.Hour,
+		},
+		{
+			name:    "invalid interval",
+			cfg:     &obsConfig.Reconciliation{Interval: aws.String("hourly")},
+			wantErr: true,
+		},
+		{
+			name:    "negative interval",
+			cfg:     &obsConfig.Reconciliation{Interval: aws.String("-1h")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, remove, err := parseReconciliation(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.interval, interval)
+			assert.Equal(t, tt.remove, remove)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	old := time.Now().Add(-2 * orphanGracePeriod)
+	fresh := time.Now()
+
+	usedPrincipal := BuildPrincipalString("used", testNamespace)
+	gonePrincipal := BuildPrincipalString("gone", testNamespace)
+
+	bucketPolicy := func(principals ...string) string {
+		document := policy.Document{Version: bucketVersion}
+		for _, principal := range principals {
+			document.Statement = append(document.Statement, policy.StatementEntry{
+				Effect:    allowEffect,
+				Principal: map[string]string{"AWS": principal},
+				Sid:       PolicySid,
+			})
+		}
+
+		raw, err := json.Marshal(document)
+		require.NoError(t, err)
+
+		return string(raw)
+	}
+
+	// expectIAM sets up the users, of which only "orphan" is reported.
+	// The user "other" belongs to a different connection of the same cluster.
+	expectIAM := func(iamMock *omocks.IAM) {
+		iamMock.On("GetUser", mock.Anything, &iam.GetUserInput{UserName: aws.String("used")}).Return(&iam.GetUserOutput{}, nil).Once()
+		iamMock.On("GetUser", mock.Anything, &iam.GetUserInput{UserName: aws.String("gone")}).Return(nil, &types.NoSuchEntityException{}).Once()
+		iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/" + testClusterID + "/")}).Return(&iam.ListUsersOutput{
+			Users: []types.User{
+				{UserName: aws.String("used"), CreateDate: &old},
+				{UserName: aws.String("orphan"), CreateDate: &old},
+				{UserName: aws.String("fresh"), CreateDate: &fresh},
+				{UserName: aws.String("grouped"), CreateDate: &old},
+				{UserName: aws.String("other"), CreateDate: &old},
+			},
+		}, nil).Once()
+		for _, userName := range []string{"used", "orphan", "fresh", "grouped"} {
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String(userName)}).Return(&iam.ListUserTagsOutput{
+				Tags: (&Server{clusterID: testClusterID, backendID: testID}).ownershipTags("bucket", userName),
+			}, nil).Once()
+		}
+		iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String("other")}).Return(&iam.ListUserTagsOutput{
+			Tags: (&Server{clusterID: testClusterID, backendID: "other.id"}).ownershipTags("bucket", "other"),
+		}, nil).Once()
+		iamMock.On("ListGroupsForUser", mock.Anything, &iam.ListGroupsForUserInput{UserName: aws.String("orphan")}).Return(&iam.ListGroupsForUserOutput{
+			Groups: []types.Group{{GroupName: aws.String("unrelated")}},
+		}, nil).Once()
+		iamMock.On("ListGroupsForUser", mock.Anything, &iam.ListGroupsForUserInput{UserName: aws.String("grouped")}).Return(&iam.ListGroupsForUserOutput{
+			Groups: []types.Group{{GroupName: aws.String(BuildGroupName("bucket", AccessModeReadWrite))}},
+		}, nil).Once()
+	}
+
+	expected := &driver.OrphanReport{
+		Users:      []driver.OrphanUser{{Namespace: testNamespace, Name: "orphan"}},
+		Statements: []driver.OrphanStatement{{Bucket: "bucket", Principal: gonePrincipal}},
+	}
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"DryRun": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			bucketsMock := mocks.NewBucketServiceInterface(t)
+			bucketsMock.On("List", mock.Anything, map[string]string{"namespace": testNamespace}).Return(&model.BucketList{
+				Buckets: []model.Bucket{{Name: "bucket"}, {Name: "empty"}},
+			}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "bucket", mock.Anything).Return(bucketPolicy(usedPrincipal, gonePrincipal), nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "empty", mock.Anything).Return("", nil).Once()
+
+			mgmtClientMock := mocks.NewClientSet(t)
+			mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+			iamMock := omocks.NewIAM(t)
+			expectIAM(iamMock)
+
+			server := Server{
+				mgmtClient: mgmtClientMock,
+				namespace:  testNamespace,
+				backendID:  testID,
+				clusterID:  testClusterID,
+				iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			report, err := server.Reconcile(ctx, false)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, report)
+		},
+		"RemovesOrphans": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			bucketsMock := mocks.NewBucketServiceInterface(t)
+			bucketsMock.On("List", mock.Anything, mock.Anything).Return(&model.BucketList{
+				Buckets: []model.Bucket{{Name: "bucket"}},
+			}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "bucket", mock.Anything).Return(bucketPolicy(usedPrincipal, gonePrincipal), nil).Twice()
+			bucketsMock.On("UpdatePolicy", mock.Anything, "bucket", bucketPolicy(usedPrincipal), mock.Anything).Return(nil).Once()
+
+			mgmtClientMock := mocks.NewClientSet(t)
+			mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+			iamMock := omocks.NewIAM(t)
+			expectIAM(iamMock)
+			iamMock.On("ListGroupsForUser", mock.Anything, &iam.ListGroupsForUserInput{UserName: aws.String("orphan")}).Return(&iam.ListGroupsForUserOutput{}, nil).Once()
+			iamMock.On("ListAccessKeys", mock.Anything, &iam.ListAccessKeysInput{UserName: aws.String("orphan")}).Return(&iam.ListAccessKeysOutput{
+				AccessKeyMetadata: []types.AccessKeyMetadata{{AccessKeyId: aws.String("key")}},
+			}, nil).Once()
+			iamMock.On("DeleteAccessKey", mock.Anything, &iam.DeleteAccessKeyInput{
+				AccessKeyId: aws.String("key"),
+				UserName:    aws.String("orphan"),
+			}).Return(&iam.DeleteAccessKeyOutput{}, nil).Once()
+			iamMock.On("DeleteUser", mock.Anything, &iam.DeleteUserInput{UserName: aws.String("orphan")}).Return(&iam.DeleteUserOutput{}, nil).Once()
+
+			server := Server{
+				mgmtClient: mgmtClientMock,
+				namespace:  testNamespace,
+				backendID:  testID,
+				clusterID:  testClusterID,
+				iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			report, err := server.Reconcile(ctx, true)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Users, report.Users)
+			assert.Equal(t, expected.Statements, report.Statements)
+			assert.True(t, report.Removed)
+		},
+		"ErrorListingBuckets": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			bucketsMock := mocks.NewBucketServiceInterface(t)
+			bucketsMock.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			mgmtClientMock := mocks.NewClientSet(t)
+			mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+			server := Server{
+				mgmtClient: mgmtClientMock,
+				namespace:  testNamespace,
+				clusterID:  testClusterID,
+			}
+
+			_, err := server.Reconcile(ctx, false)
+			assert.Error(t, err)
+		},
+		"ErrorGettingPolicyIsReported": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			bucketsMock := mocks.NewBucketServiceInterface(t)
+			bucketsMock.On("List", mock.Anything, mock.Anything).Return(&model.BucketList{
+				Buckets: []model.Bucket{{Name: "bucket"}},
+			}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("failed")).Once()
+
+			mgmtClientMock := mocks.NewClientSet(t)
+			mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+			iamMock := omocks.NewIAM(t)
+			iamMock.On("ListUsers", mock.Anything, mock.Anything).Return(&iam.ListUsersOutput{}, nil).Once()
+
+			server := Server{
+				mgmtClient: mgmtClientMock,
+				namespace:  testNamespace,
+				clusterID:  testClusterID,
+				iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			report, err := server.Reconcile(ctx, false)
+			assert.Error(t, err)
+			assert.NotNil(t, report)
+		},
+		"ErrorGettingPolicySkipsRemoval": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			bucketsMock := mocks.NewBucketServiceInterface(t)
+			bucketsMock.On("List", mock.Anything, mock.Anything).Return(&model.BucketList{
+				Buckets: []model.Bucket{{Name: "bucket"}, {Name: "unreadable"}},
+			}, nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "bucket", mock.Anything).Return(bucketPolicy(usedPrincipal, gonePrincipal), nil).Once()
+			bucketsMock.On("GetPolicy", mock.Anything, "unreadable", mock.Anything).Return("", errors.New("failed")).Once()
+
+			mgmtClientMock := mocks.NewClientSet(t)
+			mgmtClientMock.On("Buckets").Return(bucketsMock)
+
+			// no user is deleted and no policy is updated, as the mocks fail on unexpected calls
+			iamMock := omocks.NewIAM(t)
+			expectIAM(iamMock)
+
+			server := Server{
+				mgmtClient: mgmtClientMock,
+				namespace:  testNamespace,
+				backendID:  testID,
+				clusterID:  testClusterID,
+				iamClient:  func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			report, err := server.Reconcile(ctx, true)
+			assert.ErrorContains(t, err, "orphans are not removed")
+			assert.Equal(t, expected.Users, report.Users)
+			assert.False(t, report.Removed)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestPrincipalExists(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String("role")}).Return(nil, &types.NoSuchEntityException{}).Once()
+
+	server := Server{
+		namespace: testNamespace,
+		iamClient: func(context.Context) (IAM, error) { return iamMock, nil },
+	}
+
+	exists, err := server.principalExists(ctx, BuildRoleARN("role", testNamespace))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Principals not managed by the driver are never reported.
+	for _, principal := range []string{"*", BuildPrincipalString("user", "other"), principalPrefix + testNamespace + ":root"} {
+		exists, err = server.principalExists(ctx, principal)
+		assert.NoError(t, err)
+		assert.True(t, exists, principal)
+	}
+}
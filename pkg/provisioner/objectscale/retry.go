@@ -0,0 +1,385 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/dell/goobjectscale/pkg/client/api"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+const (
+	// retryMaxAttempts is the number of attempts of a single call to ObjectScale, including the first one.
+	retryMaxAttempts = 4
+	// retryBaseDelay is the upper bound of the delay before the first retry, doubled with every retry.
+	retryBaseDelay = 200 * time.Millisecond
+	// retryMaxDelay is the upper bound of the delay between the attempts.
+	retryMaxDelay = 5 * time.Second
+)
+
+// retryables classify the errors of the calls to ObjectScale. Errors flagged as retryable by ObjectScale,
+// network errors, 5xx responses and throttling are retried, all other errors are permanent.
+var retryables = retry.IsErrorRetryables(append([]retry.IsErrorRetryable{
+	retry.IsErrorRetryableFunc(isRetryableObjectscaleError),
+}, retry.DefaultRetryables...))
+
+// isRetryableObjectscaleError classifies the errors returned by the ObjectScale management API.
+func isRetryableObjectscaleError(err error) aws.Ternary {
+	objectscaleErr, ok := asObjectscaleError(err)
+	if !ok {
+		return aws.UnknownTernary
+	}
+
+	return aws.BoolTernary(objectscaleErr.Retryable)
+}
+
+// isRetryable reports whether the idempotent call failed with a transient error.
+func isRetryable(err error) bool {
+	return retryables.IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// isUnsent reports whether the call failed before the request was sent, because the connection
+// to ObjectScale could not be established. Only such errors are safe to retry for the calls,
+// which are not idempotent, as ObjectScale may have applied the request failed in any other way.
+func isUnsent(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retriesExhaustedError is returned when the call to ObjectScale failed with a retryable error on every attempt,
+// or when the deadline of the operation does not leave time for another attempt.
+type retriesExhaustedError struct {
+	attempts int
+	err      error
+}
+
+func (e *retriesExhaustedError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", e.attempts, e.err)
+}
+
+func (e *retriesExhaustedError) Unwrap() error {
+	return e.err
+}
+
+// retryer retries the calls to ObjectScale failed with a transient error, with exponential backoff and full jitter.
+// Nil retryer makes a single attempt.
+type retryer struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// newRetryer creates retryer with the default settings.
+func newRetryer() *retryer {
+	return &retryer{
+		maxAttempts: retryMaxAttempts,
+		baseDelay:   retryBaseDelay,
+		maxDelay:    retryMaxDelay,
+	}
+}
+
+// delay returns random delay before the retry, up to the base delay doubled with every attempt.
+func (r *retryer) delay(attempt int) time.Duration {
+	limit := r.maxDelay
+	if shift := attempt - 1; shift < 32 && r.baseDelay<<shift < limit {
+		limit = r.baseDelay << shift
+	}
+
+	if limit <= 0 {
+		return 0
+	}
+
+	return rand.N(limit)
+}
+
+// retryCall calls ObjectScale until it succeeds, fails with a permanent error, or the attempts are exhausted.
+// Retries are made only within the deadline of the context. It must be used only for idempotent calls.
+func retryCall[T any](ctx context.Context, r *retryer, call func(context.Context) (T, error)) (T, error) {
+	return retryWhile(ctx, r, isRetryable, call)
+}
+
+// retryUnsentCall retries the call, which is not idempotent, only if it failed before the request was sent.
+// Repeating a request, which ObjectScale may have already applied, could e.g. create a second access key,
+// or fail with EntityAlreadyExists although the entity was created by the first attempt.
+func retryUnsentCall[T any](ctx context.Context, r *retryer, call func(context.Context) (T, error)) (T, error) {
+	return retryWhile(ctx, r, isUnsent, call)
+}
+
+// retryWhile calls ObjectScale until it succeeds, fails with an error which is not retryable,
+// or the attempts are exhausted. Retries are made only within the deadline of the context.
+func retryWhile[T any](ctx context.Context, r *retryer, retryable func(error) bool, call func(context.Context) (T, error)) (T, error) {
+	if r == nil {
+		return call(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := call(ctx)
+		if err == nil || ctx.Err() != nil || !retryable(err) {
+			return result, err
+		}
+
+		if attempt >= r.maxAttempts {
+			return result, &retriesExhaustedError{attempts: attempt, err: err}
+		}
+
+		delay := r.delay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return result, &retriesExhaustedError{attempts: attempt, err: err}
+		}
+
+		log.Debugf("Retrying call to ObjectScale in %s after attempt %d failed: %v", delay, attempt, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryOnly adapts calls returning only an error to retryCall.
+func retryOnly(ctx context.Context, r *retryer, call func(context.Context) error) error {
+	_, err := retryCall(ctx, r, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, call(ctx)
+	})
+
+	return err
+}
+
+// retryingClientSet retries the calls to the ObjectScale management API.
+// Creation of buckets and update of bucket policies are retried only if the request was not sent,
+// as a repeated update could overwrite the policy changed concurrently since the first attempt.
+type retryingClientSet struct {
+	api.ClientSet
+	retryer *retryer
+}
+
+var _ api.ClientSet = (*retryingClientSet)(nil)
+
+func (c *retryingClientSet) Buckets() api.BucketServiceInterface {
+	return &retryingBuckets{BucketServiceInterface: c.ClientSet.Buckets(), retryer: c.retryer}
+}
+
+func (c *retryingClientSet) VPools() api.VPoolServiceInterface {
+	return &retryingVPools{VPoolServiceInterface: c.ClientSet.VPools(), retryer: c.retryer}
+}
+
+type retryingBuckets struct {
+	api.BucketServiceInterface
+	retryer *retryer
+}
+
+func (b *retryingBuckets) Create(ctx context.Context, createParam *model.ObjectBucketParam) (*model.Bucket, error) {
+	return retryUnsentCall(ctx, b.retryer, func(ctx context.Context) (*model.Bucket, error) {
+		return b.BucketServiceInterface.Create(ctx, createParam)
+	})
+}
+
+func (b *retryingBuckets) Get(ctx context.Context, bucketName string, param map[string]string) (*model.Bucket, error) {
+	return retryCall(ctx, b.retryer, func(ctx context.Context) (*model.Bucket, error) {
+		return b.BucketServiceInterface.Get(ctx, bucketName, param)
+	})
+}
+
+func (b *retryingBuckets) List(ctx context.Context, param map[string]string) (*model.BucketList, error) {
+	return retryCall(ctx, b.retryer, func(ctx context.Context) (*model.BucketList, error) {
+		return b.BucketServiceInterface.List(ctx, param)
+	})
+}
+
+func (b *retryingBuckets) Delete(ctx context.Context, bucketName string, param map[string]string) error {
+	return retryOnly(ctx, b.retryer, func(ctx context.Context) error {
+		return b.BucketServiceInterface.Delete(ctx, bucketName, param)
+	})
+}
+
+func (b *retryingBuckets) UpdatePolicy(ctx context.Context, bucketName string, policy string, param map[string]string) error {
+	_, err := retryUnsentCall(ctx, b.retryer, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, b.BucketServiceInterface.UpdatePolicy(ctx, bucketName, policy, param)
+	})
+
+	return err
+}
+
+func (b *retryingBuckets) GetPolicy(ctx context.Context, bucketName string, param map[string]string) (string, error) {
+	return retryCall(ctx, b.retryer, func(ctx context.Context) (string, error) {
+		return b.BucketServiceInterface.GetPolicy(ctx, bucketName, param)
+	})
+}
+
+func (b *retryingBuckets) DeletePolicy(ctx context.Context, bucketName string, param map[string]string) error {
+	return retryOnly(ctx, b.retryer, func(ctx context.Context) error {
+		return b.BucketServiceInterface.DeletePolicy(ctx, bucketName, param)
+	})
+}
+
+type retryingVPools struct {
+	api.VPoolServiceInterface
+	retryer *retryer
+}
+
+func (v *retryingVPools) List(ctx context.Context) ([]model.DataServiceVPool, error) {
+	return retryCall(ctx, v.retryer, func(ctx context.Context) ([]model.DataServiceVPool, error) {
+		return v.VPoolServiceInterface.List(ctx)
+	})
+}
+
+// retryingIAM retries the calls to the ObjectScale IAM API.
+// Retries of the AWS SDK are disabled, so the attempts are not multiplied.
+// Creations are not idempotent, so they are retried only if the request was not sent.
+type retryingIAM struct {
+	IAM
+	retryer *retryer
+}
+
+var _ IAM = (*retryingIAM)(nil)
+
+func (r *retryingIAM) AddUserToGroup(ctx context.Context, params *iam.AddUserToGroupInput, optFns ...func(*iam.Options)) (*iam.AddUserToGroupOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.AddUserToGroupOutput, error) {
+		return r.IAM.AddUserToGroup(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) AttachGroupPolicy(ctx context.Context, params *iam.AttachGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.AttachGroupPolicyOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.AttachGroupPolicyOutput, error) {
+		return r.IAM.AttachGroupPolicy(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) CreateAccessKey(ctx context.Context, params *iam.CreateAccessKeyInput, optFns ...func(*iam.Options)) (*iam.CreateAccessKeyOutput, error) {
+	return retryUnsentCall(ctx, r.retryer, func(ctx context.Context) (*iam.CreateAccessKeyOutput, error) {
+		return r.IAM.CreateAccessKey(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) CreateGroup(ctx context.Context, params *iam.CreateGroupInput, optFns ...func(*iam.Options)) (*iam.CreateGroupOutput, error) {
+	return retryUnsentCall(ctx, r.retryer, func(ctx context.Context) (*iam.CreateGroupOutput, error) {
+		return r.IAM.CreateGroup(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) CreatePolicy(ctx context.Context, params *iam.CreatePolicyInput, optFns ...func(*iam.Options)) (*iam.CreatePolicyOutput, error) {
+	return retryUnsentCall(ctx, r.retryer, func(ctx context.Context) (*iam.CreatePolicyOutput, error) {
+		return r.IAM.CreatePolicy(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) CreateRole(ctx context.Context, params *iam.CreateRoleInput, optFns ...func(*iam.Options)) (*iam.CreateRoleOutput, error) {
+	return retryUnsentCall(ctx, r.retryer, func(ctx context.Context) (*iam.CreateRoleOutput, error) {
+		return r.IAM.CreateRole(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) CreateUser(ctx context.Context, params *iam.CreateUserInput, optFns ...func(*iam.Options)) (*iam.CreateUserOutput, error) {
+	return retryUnsentCall(ctx, r.retryer, func(ctx context.Context) (*iam.CreateUserOutput, error) {
+		return r.IAM.CreateUser(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) DeleteAccessKey(ctx context.Context, params *iam.DeleteAccessKeyInput, optFns ...func(*iam.Options)) (*iam.DeleteAccessKeyOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.DeleteAccessKeyOutput, error) {
+		return r.IAM.DeleteAccessKey(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) DeleteGroup(ctx context.Context, params *iam.DeleteGroupInput, optFns ...func(*iam.Options)) (*iam.DeleteGroupOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.DeleteGroupOutput, error) {
+		return r.IAM.DeleteGroup(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) DeletePolicy(ctx context.Context, params *iam.DeletePolicyInput, optFns ...func(*iam.Options)) (*iam.DeletePolicyOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.DeletePolicyOutput, error) {
+		return r.IAM.DeletePolicy(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) DeleteRole(ctx context.Context, params *iam.DeleteRoleInput, optFns ...func(*iam.Options)) (*iam.DeleteRoleOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.DeleteRoleOutput, error) {
+		return r.IAM.DeleteRole(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) DeleteUser(ctx context.Context, params *iam.DeleteUserInput, optFns ...func(*iam.Options)) (*iam.DeleteUserOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.DeleteUserOutput, error) {
+		return r.IAM.DeleteUser(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) DetachGroupPolicy(ctx context.Context, params *iam.DetachGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.DetachGroupPolicyOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.DetachGroupPolicyOutput, error) {
+		return r.IAM.DetachGroupPolicy(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) GetGroup(ctx context.Context, params *iam.GetGroupInput, optFns ...func(*iam.Options)) (*iam.GetGroupOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.GetGroupOutput, error) {
+		return r.IAM.GetGroup(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.GetRoleOutput, error) {
+		return r.IAM.GetRole(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) GetUser(ctx context.Context, params *iam.GetUserInput, optFns ...func(*iam.Options)) (*iam.GetUserOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.GetUserOutput, error) {
+		return r.IAM.GetUser(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) ListAccessKeys(ctx context.Context, params *iam.ListAccessKeysInput, optFns ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.ListAccessKeysOutput, error) {
+		return r.IAM.ListAccessKeys(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) ListGroupsForUser(ctx context.Context, params *iam.ListGroupsForUserInput, optFns ...func(*iam.Options)) (*iam.ListGroupsForUserOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.ListGroupsForUserOutput, error) {
+		return r.IAM.ListGroupsForUser(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) ListUserTags(ctx context.Context, params *iam.ListUserTagsInput, optFns ...func(*iam.Options)) (*iam.ListUserTagsOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.ListUserTagsOutput, error) {
+		return r.IAM.ListUserTags(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) ListUsers(ctx context.Context, params *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.ListUsersOutput, error) {
+		return r.IAM.ListUsers(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) RemoveUserFromGroup(ctx context.Context, params *iam.RemoveUserFromGroupInput, optFns ...func(*iam.Options)) (*iam.RemoveUserFromGroupOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.RemoveUserFromGroupOutput, error) {
+		return r.IAM.RemoveUserFromGroup(ctx, params, optFns...)
+	})
+}
+
+func (r *retryingIAM) TagUser(ctx context.Context, params *iam.TagUserInput, optFns ...func(*iam.Options)) (*iam.TagUserOutput, error) {
+	return retryCall(ctx, r.retryer, func(ctx context.Context) (*iam.TagUserOutput, error) {
+		return r.IAM.TagUser(ctx, params, optFns...)
+	})
+}
@@ -0,0 +1,254 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+)
+
+var (
+	errTransient = model.Error{Code: 6503, Description: "service unavailable", Retryable: true}
+	errPermanent = model.Error{Code: 1004, Description: "parameter not found"}
+)
+
+// testRetryer retries without noticeable delay.
+func testRetryer() *retryer {
+	return &retryer{maxAttempts: 3, baseDelay: time.Millisecond, maxDelay: time.Millisecond}
+}
+
+// errDial is returned when the connection to ObjectScale could not be established.
+var errDial = &url.Error{Op: "Post", URL: "https://objectscale", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+
+func httpStatusError(statusCode int) error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: statusCode}},
+		Err:      errors.New("response error"),
+	}
+}
+
+func TestRetryables(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "retryable ObjectScale error", err: errTransient, retryable: true},
+		{name: "permanent ObjectScale error", err: errPermanent},
+		{name: "retryable ObjectScale error returned by the client", err: fmt.Errorf("server error: %w", &errTransient), retryable: true},
+		{name: "connection reset", err: errors.New("read tcp: connection reset by peer"), retryable: true},
+		{name: "service unavailable", err: httpStatusError(http.StatusServiceUnavailable), retryable: true},
+		{name: "not found", err: httpStatusError(http.StatusNotFound)},
+		{name: "throttling", err: &smithy.GenericAPIError{Code: "Throttling"}, retryable: true},
+		{name: "no such entity", err: &smithy.GenericAPIError{Code: "NoSuchEntity"}},
+		{name: "unknown error", err: errors.New("failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retryable, retryables.IsErrorRetryable(tt.err) == aws.TrueTernary)
+		})
+	}
+}
+
+func TestIsUnsent(t *testing.T) {
+	assert.True(t, isUnsent(errDial))
+	assert.True(t, isRetryable(errDial))
+	assert.False(t, isUnsent(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}))
+	assert.False(t, isUnsent(httpStatusError(http.StatusServiceUnavailable)))
+	assert.False(t, isUnsent(errTransient))
+}
+
+func TestRetryerDelay(t *testing.T) {
+	r := &retryer{baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+
+	for attempt := 1; attempt < 40; attempt++ {
+		delay := r.delay(attempt)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.Less(t, delay, time.Second)
+
+		if attempt == 1 {
+			assert.Less(t, delay, 100*time.Millisecond)
+		}
+	}
+}
+
+func TestRetryCall(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"SucceededAfterRetries": func(t *testing.T) {
+			attempts := 0
+			result, err := retryCall(context.Background(), testRetryer(), func(_ context.Context) (string, error) {
+				attempts++
+				if attempts < 3 {
+					return "", errTransient
+				}
+
+				return "ok", nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", result)
+			assert.Equal(t, 3, attempts)
+		},
+		"PermanentError": func(t *testing.T) {
+			attempts := 0
+			err := retryOnly(context.Background(), testRetryer(), func(_ context.Context) error {
+				attempts++
+				return errPermanent
+			})
+
+			assert.ErrorIs(t, err, errPermanent)
+			assert.Equal(t, 1, attempts)
+		},
+		"RetriesExhausted": func(t *testing.T) {
+			attempts := 0
+			err := retryOnly(context.Background(), testRetryer(), func(_ context.Context) error {
+				attempts++
+				return errTransient
+			})
+
+			var exhausted *retriesExhaustedError
+			require.ErrorAs(t, err, &exhausted)
+			assert.Equal(t, 3, exhausted.attempts)
+			assert.Equal(t, 3, attempts)
+			assert.ErrorIs(t, err, errTransient)
+		},
+		"DeadlineTooShort": func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+
+			attempts := 0
+			err := retryOnly(ctx, &retryer{maxAttempts: 3, baseDelay: time.Hour, maxDelay: time.Hour}, func(_ context.Context) error {
+				attempts++
+				return errTransient
+			})
+
+			var exhausted *retriesExhaustedError
+			assert.ErrorAs(t, err, &exhausted)
+			assert.Equal(t, 1, attempts)
+		},
+		"NoRetryer": func(t *testing.T) {
+			attempts := 0
+			err := retryOnly(context.Background(), nil, func(_ context.Context) error {
+				attempts++
+				return errTransient
+			})
+
+			assert.ErrorIs(t, err, errTransient)
+			assert.Equal(t, 1, attempts)
+		},
+		"UnsentOnly": func(t *testing.T) {
+			attempts := 0
+			_, err := retryUnsentCall(context.Background(), testRetryer(), func(_ context.Context) (string, error) {
+				attempts++
+				if attempts < 2 {
+					return "", errDial
+				}
+
+				return "", errTransient
+			})
+
+			assert.ErrorIs(t, err, errTransient)
+			assert.Equal(t, 2, attempts)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestRetryingClientSet(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, testBucketName, mock.Anything).Return(nil, errTransient).Once()
+	bucketsMock.On("Get", mock.Anything, testBucketName, mock.Anything).Return(&model.Bucket{Name: testBucketName}, nil).Once()
+	bucketsMock.On("DeletePolicy", mock.Anything, testBucketName, mock.Anything).Return(errPermanent).Once()
+	bucketsMock.On("Create", mock.Anything, mock.Anything).Return(nil, errTransient).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, "policy", mock.Anything).Return(errDial).Once()
+	bucketsMock.On("UpdatePolicy", mock.Anything, testBucketName, "policy", mock.Anything).Return(errTransient).Once()
+
+	vpoolsMock := mocks.NewVPoolServiceInterface(t)
+	vpoolsMock.On("List", mock.Anything).Return(nil, errTransient).Once()
+	vpoolsMock.On("List", mock.Anything).Return([]model.DataServiceVPool{{ID: "vpool"}}, nil).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock).Times(4)
+	mgmtClientMock.On("VPools").Return(vpoolsMock).Once()
+
+	client := &retryingClientSet{ClientSet: mgmtClientMock, retryer: testRetryer()}
+
+	bucket, err := client.Buckets().Get(ctx, testBucketName, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, testBucketName, bucket.Name)
+
+	err = client.Buckets().DeletePolicy(ctx, testBucketName, nil)
+	assert.ErrorIs(t, err, errPermanent)
+
+	// mutations are retried only if the request was not sent
+	_, err = client.Buckets().Create(ctx, &model.ObjectBucketParam{Name: testBucketName})
+	assert.ErrorIs(t, err, errTransient)
+
+	err = client.Buckets().UpdatePolicy(ctx, testBucketName, "policy", nil)
+	assert.ErrorIs(t, err, errTransient)
+
+	vpools, err := client.VPools().List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, vpools, 1)
+}
+
+func TestRetryingIAM(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	iamMock := omocks.NewIAM(t)
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(nil, httpStatusError(http.StatusBadGateway)).Once()
+	iamMock.On("GetUser", mock.Anything, mock.Anything).Return(&iam.GetUserOutput{}, nil).Once()
+	iamMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil, httpStatusError(http.StatusServiceUnavailable)).Times(3)
+	iamMock.On("CreateUser", mock.Anything, mock.Anything).Return(nil, httpStatusError(http.StatusBadGateway)).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(nil, errDial).Once()
+	iamMock.On("CreateAccessKey", mock.Anything, mock.Anything).Return(&iam.CreateAccessKeyOutput{}, nil).Once()
+
+	client := &retryingIAM{IAM: iamMock, retryer: testRetryer()}
+
+	_, err := client.GetUser(ctx, &iam.GetUserInput{UserName: aws.String("user")})
+	assert.NoError(t, err)
+
+	_, err = client.DeleteUser(ctx, &iam.DeleteUserInput{UserName: aws.String("user")})
+	assert.Equal(t, codes.Unavailable, status.Code(logAndTraceError(ctx, trace.SpanFromContext(ctx), "failed deleting user", err, codes.Internal)))
+
+	// the user may have been created by the failed request, so it is not retried
+	_, err = client.CreateUser(ctx, &iam.CreateUserInput{UserName: aws.String("user")})
+	assert.Error(t, err)
+
+	_, err = client.CreateAccessKey(ctx, &iam.CreateAccessKeyInput{UserName: aws.String("user")})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,238 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"go.opentelemetry.io/otel"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/provisioner/policy"
+)
+
+const (
+	// ServiceAccountParam is the BucketAccessClass parameter with the Kubernetes ServiceAccount, in form of
+	// <namespace>/<name>, which is allowed to assume the role created for the bucket access with IAM authentication.
+	ServiceAccountParam = "serviceAccount"
+
+	// RoleARNSecretKey is the key of the credentials secret holding ARN of the role to be assumed by the workload.
+	RoleARNSecretKey = "roleArn"
+
+	webIdentityAction = "sts:AssumeRoleWithWebIdentity"
+)
+
+// oidcProvider is the OpenID Connect provider trusted by the roles created for bucket access.
+type oidcProvider struct {
+	// issuer is the issuer URL without the scheme, as used in the provider ARN and condition keys.
+	issuer   string
+	audience string
+}
+
+// newOIDCProvider validates the OpenID Connect provider configuration. Nil is returned if it is not configured.
+func newOIDCProvider(cfg *obsConfig.OidcProvider) (*oidcProvider, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	issuer, err := url.Parse(cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OIDC issuer %s: %w", cfg.Issuer, err)
+	}
+
+	if issuer.Scheme != "https" || issuer.Host == "" {
+		return nil, fmt.Errorf("invalid OIDC issuer %s: expected https URL", cfg.Issuer)
+	}
+
+	return &oidcProvider{
+		issuer:   strings.TrimSuffix(issuer.Host+issuer.Path, "/"),
+		audience: aws.ToString(cfg.Audience),
+	}, nil
+}
+
+// trustPolicyStatement is a statement of the role trust policy. Unlike the bucket policy statements,
+// it has no resources.
+type trustPolicyStatement struct {
+	Effect    string            `json:"Effect"`
+	Principal map[string]string `json:"Principal"`
+	Action    []string          `json:"Action"`
+	Condition policy.Condition  `json:"Condition"`
+}
+
+type trustPolicyDocument struct {
+	Version   string                 `json:"Version"`
+	Statement []trustPolicyStatement `json:"Statement"`
+}
+
+// trustPolicy builds the trust policy allowing the ServiceAccount to assume the role with its token.
+func (p *oidcProvider) trustPolicy(namespace, serviceAccountNamespace, serviceAccountName string) (string, error) {
+	statement := trustPolicyStatement{
+		Effect:    allowEffect,
+		Principal: map[string]string{"Federated": fmt.Sprintf("%s%s:oidc-provider/%s", principalPrefix, namespace, p.issuer)},
+		Action:    []string{webIdentityAction},
+		Condition: policy.Condition{"StringEquals": {
+			p.issuer + ":sub": {fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccountNamespace, serviceAccountName)},
+		}},
+	}
+
+	if p.audience != "" {
+		statement.Condition["StringEquals"][p.issuer+":aud"] = policy.ConditionValues{p.audience}
+	}
+
+	document, err := json.Marshal(trustPolicyDocument{Version: bucketVersion, Statement: []trustPolicyStatement{statement}})
+	if err != nil {
+		return "", err
+	}
+
+	return string(document), nil
+}
+
+// authenticationType validates the requested authentication type and reports if IAM authentication is requested.
+// Unknown authentication type is treated as key authentication, for compatibility with older sidecars.
+func (s *Server) authenticationType(req *cosi.DriverGrantBucketAccessRequest) (bool, error) {
+	switch authType := req.GetAuthenticationType(); authType {
+	case cosi.AuthenticationType_UnknownAuthenticationType, cosi.AuthenticationType_Key:
+		return false, nil
+
+	case cosi.AuthenticationType_IAM:
+		if s.oidcProvider == nil {
+			return false, fmt.Errorf("authentication type %s requires OIDC provider configuration", authType)
+		}
+
+		if s.groupAccessManagement() {
+			return false, fmt.Errorf("authentication type %s is not supported with group access management", authType)
+		}
+
+		for _, param := range []string{TTLParam, UserNamespaceParam} {
+			if strings.TrimSpace(req.Parameters[param]) != "" {
+				return false, fmt.Errorf("parameter %s is not supported with authentication type %s", param, authType)
+			}
+		}
+
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unsupported authentication type %s", authType)
+	}
+}
+
+// parseServiceAccount returns namespace and name of the ServiceAccount from the BucketAccessClass parameters.
+func parseServiceAccount(parameters map[string]string) (string, string, error) {
+	raw := strings.TrimSpace(parameters[ServiceAccountParam])
+
+	namespace, name, ok := strings.Cut(raw, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid service account %q, expected <namespace>/<name>", raw)
+	}
+
+	return namespace, name, nil
+}
+
+// BuildRoleName builds name of the IAM role for the bucket access.
+func BuildRoleName(namespace, access string) string {
+	return truncateName(fmt.Sprintf("%v-role-%v", namespace, access))
+}
+
+// BuildRoleARN builds ARN of the IAM role, which is also the account ID of the bucket access with IAM authentication.
+func BuildRoleARN(roleName, namespace string) string {
+	return fmt.Sprintf("%s%s:role/%s", principalPrefix, namespace, roleName)
+}
+
+// parseRoleARN returns the role name from the account ID built by BuildRoleARN.
+// False is returned if the account ID does not identify a role.
+func (s *Server) parseRoleARN(accountID string) (string, bool, error) {
+	if !strings.HasPrefix(accountID, principalPrefix) {
+		return "", false, nil
+	}
+
+	namespace, roleName, ok := strings.Cut(strings.TrimPrefix(accountID, principalPrefix), ":role/")
+	if !ok {
+		return "", false, nil
+	}
+
+	if namespace != s.namespace || roleName == "" {
+		return "", true, fmt.Errorf("invalid role %s", accountID)
+	}
+
+	return roleName, true, nil
+}
+
+// ensureRole creates the role assumable by the ServiceAccount, if it does not exist yet.
+// Existing role is reused only if it was created for the same BucketAccess.
+func (s *Server) ensureRole(
+	ctx context.Context,
+	iamClient IAM,
+	roleName, bucketName, accessName, serviceAccountNamespace, serviceAccountName string,
+) error {
+	ctx, span := otel.Tracer(GrantBucketAccessTraceName).Start(ctx, "ObjectscaleEnsureRole")
+	defer span.End()
+
+	role, err := iamClient.GetRole(ctx, &iam.GetRoleInput{RoleName: &roleName})
+	if err == nil {
+		log.Warnf("Role %s already exists", roleName)
+
+		if role.Role == nil {
+			return nil
+		}
+
+		return checkAccessTag(role.Role.Tags, accessName)
+	}
+
+	if !isNoSuchEntity(err) {
+		return fmt.Errorf("failed getting role %s: %w", roleName, err)
+	}
+
+	trustPolicy, err := s.oidcProvider.trustPolicy(s.namespace, serviceAccountNamespace, serviceAccountName)
+	if err != nil {
+		return fmt.Errorf("failed marshalling trust policy of role %s: %w", roleName, err)
+	}
+
+	_, err = iamClient.CreateRole(ctx, &iam.CreateRoleInput{
+		RoleName:                 &roleName,
+		AssumeRolePolicyDocument: &trustPolicy,
+		Path:                     aws.String(s.iamPath()),
+		Tags:                     s.ownershipTags(bucketName, accessName),
+	})
+	if err != nil && !isEntityAlreadyExists(err) {
+		return fmt.Errorf("failed creating role %s: %w", roleName, err)
+	}
+
+	log.Infof("Created ObjectScale IAM role %s for service account %s/%s", roleName, serviceAccountNamespace, serviceAccountName)
+
+	return nil
+}
+
+// deleteRole deletes the role, if it exists.
+func deleteRole(ctx context.Context, iamClient IAM, roleName string) error {
+	_, err := iamClient.DeleteRole(ctx, &iam.DeleteRoleInput{RoleName: &roleName})
+	if err != nil && !isNoSuchEntity(err) {
+		return err
+	}
+
+	return nil
+}
+
+// assembleRoleCredentials builds credentials of the bucket access with IAM authentication.
+// No static keys are returned, the workload assumes the role using its ServiceAccount token.
+func assembleRoleCredentials(roleARN, s3Endpoint, bucketName string) map[string]*cosi.CredentialDetails {
+	return map[string]*cosi.CredentialDetails{
+		"s3": {Secrets: map[string]string{
+			RoleARNSecretKey: roleARN,
+			"endpoint":       s3Endpoint,
+			"bucketName":     bucketName,
+		}},
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+)
+
+var testOIDCProvider = &oidcProvider{issuer: "oidc.example.com/cluster", audience: "objectscale"}
+
+func TestNewOIDCProvider(t *testing.T) {
+	provider, err := newOIDCProvider(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = newOIDCProvider(&config.OidcProvider{Issuer: "https://oidc.example.com/cluster/", Audience: aws.String("objectscale")})
+	assert.NoError(t, err)
+	assert.Equal(t, testOIDCProvider, provider)
+
+	for _, issuer := range []string{"oidc.example.com", "http://oidc.example.com", "https://", "://invalid"} {
+		_, err = newOIDCProvider(&config.OidcProvider{Issuer: issuer})
+		assert.Error(t, err, issuer)
+	}
+}
+
+func TestTrustPolicy(t *testing.T) {
+	trustPolicy, err := testOIDCProvider.trustPolicy(testNamespace, "app", "writer")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": {"Federated": "urn:ecs:iam::namespace:oidc-provider/oidc.example.com/cluster"},
+			"Action": ["sts:AssumeRoleWithWebIdentity"],
+			"Condition": {"StringEquals": {
+				"oidc.example.com/cluster:sub": ["system:serviceaccount:app:writer"],
+				"oidc.example.com/cluster:aud": ["objectscale"]
+			}}
+		}]
+	}`, trustPolicy)
+}
+
+func TestParseServiceAccount(t *testing.T) {
+	namespace, name, err := parseServiceAccount(map[string]string{ServiceAccountParam: "app/writer"})
+	assert.NoError(t, err)
+	assert.Equal(t, "app", namespace)
+	assert.Equal(t, "writer", name)
+
+	for _, raw := range []string{"", "writer", "/writer", "app/", "app/writer/extra"} {
+		_, _, err = parseServiceAccount(map[string]string{ServiceAccountParam: raw})
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestAuthenticationType(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   Server
+		req      *cosi.DriverGrantBucketAccessRequest
+		expected bool
+		wantErr  bool
+	}{
+		{
+			name:   "unknown",
+			server: Server{},
+			req:    &cosi.DriverGrantBucketAccessRequest{},
+		},
+		{
+			name:   "key",
+			server: Server{},
+			req:    &cosi.DriverGrantBucketAccessRequest{AuthenticationType: cosi.AuthenticationType_Key},
+		},
+		{
+			name:     "iam",
+			server:   Server{oidcProvider: testOIDCProvider},
+			req:      &cosi.DriverGrantBucketAccessRequest{AuthenticationType: cosi.AuthenticationType_IAM},
+			expected: true,
+		},
+		{
+			name:    "iam without OIDC provider",
+			server:  Server{},
+			req:     &cosi.DriverGrantBucketAccessRequest{AuthenticationType: cosi.AuthenticationType_IAM},
+			wantErr: true,
+		},
+		{
+			name:    "iam with group access management",
+			server:  Server{oidcProvider: testOIDCProvider, accessManagement: config.ObjectscaleAccessManagementGroup},
+			req:     &cosi.DriverGrantBucketAccessRequest{AuthenticationType: cosi.AuthenticationType_IAM},
+			wantErr: true,
+		},
+		{
+			name:   "iam with ttl",
+			server: Server{oidcProvider: testOIDCProvider},
+			req: &cosi.DriverGrantBucketAccessRequest{
+				AuthenticationType: cosi.AuthenticationType_IAM,
+				Parameters:         map[string]string{TTLParam: "1h"},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "iam with user namespace",
+			server: Server{oidcProvider: testOIDCProvider},
+			req: &cosi.DriverGrantBucketAccessRequest{
+				AuthenticationType: cosi.AuthenticationType_IAM,
+				Parameters:         map[string]string{UserNamespaceParam: testCrossNamespace},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported",
+			server:  Server{oidcProvider: testOIDCProvider},
+			req:     &cosi.DriverGrantBucketAccessRequest{AuthenticationType: cosi.AuthenticationType(42)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iamAuthentication, err := tt.server.authenticationType(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, iamAuthentication)
+		})
+	}
+}
+
+func TestParseRoleARN(t *testing.T) {
+	server := Server{namespace: testNamespace}
+
+	roleName, isRole, err := server.parseRoleARN(BuildRoleARN("role", testNamespace))
+	assert.NoError(t, err)
+	assert.True(t, isRole)
+	assert.Equal(t, "role", roleName)
+
+	for _, accountID := range []string{"user", BuildPrincipalString("user", testNamespace)} {
+		_, isRole, err = server.parseRoleARN(accountID)
+		assert.NoError(t, err)
+		assert.False(t, isRole, accountID)
+	}
+
+	_, isRole, err = server.parseRoleARN(BuildRoleARN("role", "other"))
+	assert.Error(t, err)
+	assert.True(t, isRole)
+}
+
+func TestEnsureRole(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T, iamMock *omocks.IAM) error{
+		"RoleCreated": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetRole", mock.Anything, &iam.GetRoleInput{RoleName: aws.String("role")}).Return(nil, &types.NoSuchEntityException{}).Once()
+			iamMock.On("CreateRole", mock.Anything, mock.MatchedBy(func(input *iam.CreateRoleInput) bool {
+				return aws.ToString(input.RoleName) == "role" && aws.ToString(input.AssumeRolePolicyDocument) != "" &&
+					aws.ToString(input.Path) == "/cosi/cluster/" &&
+					assert.ObjectsAreEqual((&Server{clusterID: "cluster"}).ownershipTags(testBucketName, "access"), input.Tags)
+			})).Return(&iam.CreateRoleOutput{}, nil).Once()
+
+			return nil
+		},
+		"RoleExists": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+				Role: &types.Role{Tags: accessTags("access")},
+			}, nil).Once()
+
+			return nil
+		},
+		"RoleOfDifferentAccess": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+				Role: &types.Role{Tags: accessTags("other")},
+			}, nil).Once()
+
+			return errNameCollision
+		},
+		"ErrorGettingRole": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetRole", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed getting role")
+		},
+		"ErrorCreatingRole": func(t *testing.T, iamMock *omocks.IAM) error {
+			iamMock.On("GetRole", mock.Anything, mock.Anything).Return(nil, &types.NoSuchEntityException{}).Once()
+			iamMock.On("CreateRole", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			return errors.New("failed creating role")
+		},
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			iamMock := omocks.NewIAM(t)
+			wantErr := fn(t, iamMock)
+
+			server := Server{namespace: testNamespace, oidcProvider: testOIDCProvider, clusterID: "cluster"}
+
+			err := server.ensureRole(ctx, iamMock, "role", testBucketName, "access", "app", "writer")
+			if wantErr != nil {
+				assert.ErrorContains(t, err, wantErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
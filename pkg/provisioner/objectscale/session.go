@@ -0,0 +1,140 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+const (
+	// sessionTokenHeader carries the ObjectScale session token in the IAM requests.
+	sessionTokenHeader = "X-Sds-Auth-Token"
+
+	sessionTokenMiddlewareID = "ObjectscaleSessionToken"
+)
+
+// session is the ObjectScale session shared by the management and IAM clients.
+// It serializes the logins, so requests rejected concurrently with the same token log in only once.
+type session struct {
+	mu            sync.RWMutex
+	authenticator client.Authenticator
+}
+
+var _ client.Authenticator = (*session)(nil)
+
+func newSession(authenticator client.Authenticator) *session {
+	return &session{authenticator: authenticator}
+}
+
+// IsAuthenticated implements client.Authenticator.
+func (s *session) IsAuthenticated() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.authenticator.IsAuthenticated()
+}
+
+// Login implements client.Authenticator.
+func (s *session) Login(ctx context.Context, httpClient *http.Client) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.authenticator.Login(ctx, httpClient)
+}
+
+// Token implements client.Authenticator.
+func (s *session) Token() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.authenticator.Token()
+}
+
+// refresh logs in, unless the rejected token was already replaced by a concurrent login.
+func (s *session) refresh(ctx context.Context, httpClient *http.Client, rejected string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authenticator.IsAuthenticated() && s.authenticator.Token() != rejected {
+		return nil
+	}
+
+	return s.authenticator.Login(ctx, httpClient)
+}
+
+// sessionTokenMiddleware sets the session token on the IAM requests. When the token is rejected,
+// because it expired or was revoked, it logs in again and retries the request once.
+// It runs before signing, so the retried request is signed again.
+type sessionTokenMiddleware struct {
+	session    *session
+	httpClient *http.Client
+}
+
+var _ middleware.FinalizeMiddleware = (*sessionTokenMiddleware)(nil)
+
+// ID implements middleware.FinalizeMiddleware.
+func (*sessionTokenMiddleware) ID() string {
+	return sessionTokenMiddlewareID
+}
+
+// HandleFinalize implements middleware.FinalizeMiddleware.
+func (m *sessionTokenMiddleware) HandleFinalize(
+	ctx context.Context,
+	in middleware.FinalizeInput,
+	next middleware.FinalizeHandler,
+) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return next.HandleFinalize(ctx, in)
+	}
+
+	token := m.session.Token()
+	req.Header.Set(sessionTokenHeader, token)
+
+	out, metadata, err := next.HandleFinalize(ctx, in)
+	if !isUnauthorized(err) {
+		return out, metadata, err
+	}
+
+	log.Info("ObjectScale session token was rejected, logging in again")
+
+	if err := m.session.refresh(ctx, m.httpClient, token); err != nil {
+		return out, metadata, fmt.Errorf("failed logging in after the session token was rejected: %w", err)
+	}
+
+	if err := req.RewindStream(); err != nil {
+		return out, metadata, fmt.Errorf("failed rewinding request after login: %w", err)
+	}
+
+	req.Header.Set(sessionTokenHeader, m.session.Token())
+
+	return next.HandleFinalize(ctx, in)
+}
+
+// addSessionTokenMiddleware adds the session token middleware to the IAM client stack.
+func addSessionTokenMiddleware(s *session, httpClient *http.Client) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(&sessionTokenMiddleware{session: s, httpClient: httpClient}, middleware.Before)
+	}
+}
+
+// isUnauthorized checks if the request was rejected with 401 Unauthorized.
+func isUnauthorized(err error) bool {
+	var statusErr interface{ HTTPStatusCode() int }
+
+	return errors.As(err, &statusErr) && statusErr.HTTPStatusCode() == http.StatusUnauthorized
+}
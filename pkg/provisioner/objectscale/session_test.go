@@ -0,0 +1,151 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+)
+
+// fakeAuthenticator issues a new token on every login.
+type fakeAuthenticator struct {
+	token  string
+	logins int
+}
+
+func (a *fakeAuthenticator) IsAuthenticated() bool {
+	return a.token != ""
+}
+
+func (a *fakeAuthenticator) Login(_ context.Context, _ *http.Client) error {
+	a.logins++
+	a.token = fmt.Sprintf("token-%d", a.logins)
+
+	return nil
+}
+
+func (a *fakeAuthenticator) Token() string {
+	return a.token
+}
+
+const (
+	testGetUserResponse = `<GetUserResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <GetUserResult><User><UserName>user</UserName></User></GetUserResult>
+  <ResponseMetadata><RequestId>1</RequestId></ResponseMetadata>
+</GetUserResponse>`
+	testUnauthorizedResponse = `<ErrorResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <Error><Type>Sender</Type><Code>InvalidClientTokenId</Code><Message>token expired</Message></Error>
+  <RequestId>1</RequestId>
+</ErrorResponse>`
+)
+
+func TestSessionRefresh(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	authenticator := &fakeAuthenticator{}
+	s := newSession(authenticator)
+
+	// no session yet
+	require.NoError(t, s.refresh(ctx, nil, ""))
+	assert.Equal(t, "token-1", s.Token())
+
+	// token was already replaced by a concurrent login
+	require.NoError(t, s.refresh(ctx, nil, "token-0"))
+	assert.Equal(t, 1, authenticator.logins)
+
+	// concurrent requests rejected with the same token log in once
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.refresh(ctx, nil, "token-1"))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 2, authenticator.logins)
+	assert.Equal(t, "token-2", s.Token())
+}
+
+func TestIAMClientReauthenticates(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	var tokens, bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		tokens = append(tokens, r.Header.Get(sessionTokenHeader))
+		bodies = append(bodies, string(body))
+
+		w.Header().Set("Content-Type", "text/xml")
+
+		// the first token expired
+		if r.Header.Get(sessionTokenHeader) == "token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, testUnauthorizedResponse)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, testGetUserResponse)
+	}))
+	defer server.Close()
+
+	authenticator := &fakeAuthenticator{}
+	factory := &IAMClientFactory{
+		namespace: testNamespace,
+		username:  "test-username",
+		password:  "test-password",
+		endpoint:  server.URL,
+		session:   newSession(authenticator),
+		client:    server.Client(),
+	}
+
+	iamClient, err := factory.getIAMClient(ctx)
+	require.NoError(t, err)
+
+	out, err := iamClient.GetUser(ctx, &iam.GetUserInput{UserName: aws.String("user")})
+	require.NoError(t, err)
+	assert.Equal(t, "user", aws.ToString(out.User.UserName))
+
+	assert.Equal(t, []string{"token-1", "token-2"}, tokens)
+	assert.Equal(t, 2, authenticator.logins)
+	// the retried request has the same body
+	require.Len(t, bodies, 2)
+	assert.True(t, strings.Contains(bodies[1], "UserName=user"))
+	assert.Equal(t, bodies[0], bodies[1])
+
+	// client is created once
+	cached, err := factory.getIAMClient(ctx)
+	require.NoError(t, err)
+	assert.Same(t, iamClient, cached)
+}
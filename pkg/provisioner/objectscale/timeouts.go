@@ -0,0 +1,89 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+)
+
+const (
+	// defaultCreateBucketTimeout limits the bucket creation, including all calls to ObjectScale.
+	defaultCreateBucketTimeout = 20 * time.Second
+	// defaultHTTPRequestTimeout limits a single HTTP request to the ObjectScale management and IAM APIs,
+	// so the hung request does not block the background tasks, which have no caller with deadline.
+	defaultHTTPRequestTimeout = 15 * time.Second
+)
+
+// timeouts of the driver operations and of the HTTP requests to ObjectScale.
+// Zero value means no timeout, other than the deadline of the caller.
+type timeouts struct {
+	createBucket       time.Duration
+	deleteBucket       time.Duration
+	grantBucketAccess  time.Duration
+	revokeBucketAccess time.Duration
+	httpRequest        time.Duration
+}
+
+// newTimeouts parses the timeouts from the configuration. Missing values mean the default timeouts,
+// and "0" means no timeout, so only the deadline of the caller applies.
+func newTimeouts(cfg *obsConfig.Timeouts) (timeouts, error) {
+	t := timeouts{
+		createBucket: defaultCreateBucketTimeout,
+		httpRequest:  defaultHTTPRequestTimeout,
+	}
+
+	if cfg == nil {
+		return t, nil
+	}
+
+	for _, timeout := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"createBucket", cfg.CreateBucket, &t.createBucket},
+		{"deleteBucket", cfg.DeleteBucket, &t.deleteBucket},
+		{"grantBucketAccess", cfg.GrantBucketAccess, &t.grantBucketAccess},
+		{"revokeBucketAccess", cfg.RevokeBucketAccess, &t.revokeBucketAccess},
+		{"httpRequest", cfg.HttpRequest, &t.httpRequest},
+	} {
+		raw := strings.TrimSpace(timeout.value)
+		if raw == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return timeouts{}, fmt.Errorf("invalid %s timeout %s: %w", timeout.name, raw, err)
+		}
+
+		if d < 0 {
+			return timeouts{}, fmt.Errorf("invalid %s timeout %s: must not be negative", timeout.name, raw)
+		}
+
+		*timeout.dest = d
+	}
+
+	return t, nil
+}
+
+// withTimeout limits the context with the timeout, unless it is zero.
+// Deadline of the caller is kept if it is shorter.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
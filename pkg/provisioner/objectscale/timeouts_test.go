@@ -0,0 +1,137 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	obsConfig "github.com/dell/cosi/pkg/config"
+	"github.com/dell/cosi/pkg/internal/testcontext"
+)
+
+func TestNewTimeouts(t *testing.T) {
+	// other operations are limited only by the deadline of the caller by default
+	defaults := timeouts{
+		createBucket: defaultCreateBucketTimeout,
+		httpRequest:  defaultHTTPRequestTimeout,
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *obsConfig.Timeouts
+		expected timeouts
+		wantErr  bool
+	}{
+		{
+			name:     "not configured",
+			expected: defaults,
+		},
+		{
+			name:     "empty values",
+			cfg:      &obsConfig.Timeouts{},
+			expected: defaults,
+		},
+		{
+			name: "custom values",
+			cfg: &obsConfig.Timeouts{
+				CreateBucket:       "1m",
+				DeleteBucket:       "2m",
+				GrantBucketAccess:  "30s",
+				RevokeBucketAccess: "40s",
+				HttpRequest:        "5s",
+			},
+			expected: timeouts{
+				createBucket:       time.Minute,
+				deleteBucket:       2 * time.Minute,
+				grantBucketAccess:  30 * time.Second,
+				revokeBucketAccess: 40 * time.Second,
+				httpRequest:        5 * time.Second,
+			},
+		},
+		{
+			name: "zero disables the timeout",
+			cfg: &obsConfig.Timeouts{
+				CreateBucket: "0",
+				HttpRequest:  "0s",
+			},
+			expected: timeouts{},
+		},
+		{
+			name:    "invalid value",
+			cfg:     &obsConfig.Timeouts{DeleteBucket: "forever"},
+			wantErr: true,
+		},
+		{
+			name:    "negative value",
+			cfg:     &obsConfig.Timeouts{GrantBucketAccess: "-1s"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := newTimeouts(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), 0)
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+	cancel()
+	assert.Error(t, ctx.Err())
+
+	ctx, cancel = withTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+// TestDriverDeleteBucketTimeout tests that the hung call to ObjectScale is interrupted by the operation timeout.
+func TestDriverDeleteBucketTimeout(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	bucketsMock := mocks.NewBucketServiceInterface(t)
+	bucketsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return((*model.Bucket)(nil), context.DeadlineExceeded).Once()
+
+	mgmtClientMock := mocks.NewClientSet(t)
+	mgmtClientMock.On("Buckets").Return(bucketsMock).Once()
+
+	server := Server{
+		mgmtClient: mgmtClientMock,
+		namespace:  testNamespace,
+		backendID:  testID,
+		timeouts:   timeouts{deleteBucket: 10 * time.Millisecond},
+	}
+
+	_, err := server.DriverDeleteBucket(ctx, testBucketDeletionRequest)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
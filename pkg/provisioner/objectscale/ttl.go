@@ -0,0 +1,217 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"go.opentelemetry.io/otel"
+
+	driver "github.com/dell/cosi/pkg/provisioner/virtualdriver"
+	"github.com/dell/csmlog"
+)
+
+const (
+	// TTLParam is the BucketAccessClass parameter limiting lifetime of the minted credentials, e.g. "24h".
+	// Access keys are tracked and deleted by the driver once their lifetime elapses.
+	TTLParam = "ttl"
+
+	// ExpirationSecretKey is the key of the credentials secret holding the expiration time in RFC 3339 format.
+	ExpirationSecretKey = "expiration"
+
+	// ttlTagKey is the IAM user tag storing lifetime of the user's access keys.
+	ttlTagKey = "cosi.dellemc.com/ttl"
+
+	// accessKeyReaperInterval is how often the expired access keys are looked up.
+	accessKeyReaperInterval = 5 * time.Minute
+
+	ReapAccessKeysTraceName = "ReapAccessKeys"
+)
+
+var _ driver.Worker = (*Server)(nil)
+
+// parseTTL returns lifetime of the credentials requested in the BucketAccessClass parameters.
+// Zero is returned if the lifetime is not limited.
+func parseTTL(parameters map[string]string) (time.Duration, error) {
+	raw := strings.TrimSpace(parameters[TTLParam])
+	if raw == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %s: %w", raw, err)
+	}
+
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid ttl %s: must be positive", raw)
+	}
+
+	return ttl, nil
+}
+
+// accessKeyExpiration returns time when the access key expires.
+func accessKeyExpiration(accessKey *types.AccessKey, ttl time.Duration) time.Time {
+	created := time.Now()
+	if accessKey.CreateDate != nil {
+		created = *accessKey.CreateDate
+	}
+
+	return created.Add(ttl).UTC()
+}
+
+// userTTL returns lifetime of the access keys recorded in the user tags.
+func userTTL(tags []types.Tag) (time.Duration, bool) {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) != ttlTagKey {
+			continue
+		}
+
+		ttl, err := time.ParseDuration(aws.ToString(tag.Value))
+		if err != nil || ttl <= 0 {
+			return 0, false
+		}
+
+		return ttl, true
+	}
+
+	return 0, false
+}
+
+// Start runs the reaper deleting expired access keys and the periodic reconciliation, if configured,
+// until the context is canceled. It ensures the keys are revoked even if Kubernetes never requests the revocation.
+func (s *Server) Start(ctx context.Context) {
+	ticker := time.NewTicker(accessKeyReaperInterval)
+	defer ticker.Stop()
+
+	// Receiving from nil channel blocks forever, so the reconciliation never runs if it is not configured.
+	var reconcileC <-chan time.Time
+
+	if s.reconcileInterval > 0 {
+		reconcileTicker := time.NewTicker(s.reconcileInterval)
+		defer reconcileTicker.Stop()
+
+		reconcileC = reconcileTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			err := runPass(ctx, accessKeyReaperInterval, func(ctx context.Context) error {
+				return s.reapExpiredAccessKeys(ctx, time.Now())
+			})
+			if err != nil {
+				log.WithFields(csmlog.Fields{"id": s.backendID, "error": err}).Error("failed reaping expired access keys")
+			}
+
+		case <-reconcileC:
+			err := runPass(ctx, s.reconcileInterval, func(ctx context.Context) error {
+				_, err := s.Reconcile(ctx, s.removeOrphans)
+				return err
+			})
+			if err != nil {
+				log.WithFields(csmlog.Fields{"id": s.backendID, "error": err}).Error("failed reconciling orphans")
+			}
+		}
+	}
+}
+
+// runPass runs a single pass of the background task, which must end before the next one is due,
+// so the hung calls to ObjectScale do not block the task forever, as there is no caller with deadline.
+func runPass(ctx context.Context, interval time.Duration, pass func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, interval)
+	defer cancel()
+
+	return pass(ctx)
+}
+
+// reapExpiredAccessKeys deletes access keys of the users managed by the driver, which lifetime elapsed before now.
+func (s *Server) reapExpiredAccessKeys(ctx context.Context, now time.Time) error {
+	ctx, span := otel.Tracer(ReapAccessKeysTraceName).Start(ctx, "ObjectscaleReapExpiredAccessKeys")
+	defer span.End()
+
+	var errs []error
+
+	for _, namespace := range s.userNamespaces() {
+		if err := s.reapNamespaceAccessKeys(ctx, namespace, now); err != nil {
+			errs = append(errs, fmt.Errorf("namespace %s: %w", namespace, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reapNamespaceAccessKeys deletes expired access keys of the users managed by the driver in a single namespace.
+func (s *Server) reapNamespaceAccessKeys(ctx context.Context, namespace string, now time.Time) error {
+	iamClient, err := s.iamClientFor(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("failed getting IAM client: %w", err)
+	}
+
+	var errs []error
+
+	err = s.forEachManagedUser(ctx, iamClient, namespace, func(user types.User, tags []types.Tag) error {
+		userName := aws.ToString(user.UserName)
+
+		// failure of a single user does not stop the reaper
+		if err := reapUserAccessKeys(ctx, iamClient, userName, tags, now); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", userName, err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// reapUserAccessKeys deletes expired access keys of a single user, if the user has limited lifetime of keys.
+func reapUserAccessKeys(ctx context.Context, iamClient IAM, userName string, tags []types.Tag, now time.Time) error {
+	ttl, ok := userTTL(tags)
+	if !ok {
+		return nil
+	}
+
+	accessKeyList, err := iamClient.ListAccessKeys(ctx, &iam.ListAccessKeysInput{UserName: &userName})
+	if err != nil {
+		return err
+	}
+
+	for _, accessKey := range accessKeyList.AccessKeyMetadata {
+		if accessKey.CreateDate == nil || now.Before(accessKey.CreateDate.Add(ttl)) {
+			continue
+		}
+
+		_, err = iamClient.DeleteAccessKey(ctx, &iam.DeleteAccessKeyInput{
+			AccessKeyId: accessKey.AccessKeyId, UserName: &userName,
+		})
+		if err != nil {
+			return err
+		}
+
+		log.WithFields(csmlog.Fields{
+			"user":      userName,
+			"accessKey": aws.ToString(accessKey.AccessKeyId),
+		}).Info("Deleted expired access key")
+	}
+
+	return nil
+}
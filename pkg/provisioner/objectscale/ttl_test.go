@@ -0,0 +1,249 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+)
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		expected   time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "not set",
+			parameters: map[string]string{},
+			expected:   0,
+		},
+		{
+			name:       "valid",
+			parameters: map[string]string{TTLParam: "24h"},
+			expected:   24 * time.Hour,
+		},
+		{
+			name:       "invalid",
+			parameters: map[string]string{TTLParam: "1 day"},
+			wantErr:    true,
+		},
+		{
+			name:       "negative",
+			parameters: map[string]string{TTLParam: "-1h"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := parseTTL(tt.parameters)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ttl)
+		})
+	}
+}
+
+func TestUserTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []types.Tag
+		expected time.Duration
+		ok       bool
+	}{
+		{
+			name: "no tags",
+		},
+		{
+			name:     "ttl tag",
+			tags:     []types.Tag{{Key: aws.String("other"), Value: aws.String("x")}, {Key: aws.String(ttlTagKey), Value: aws.String("1h0m0s")}},
+			expected: time.Hour,
+			ok:       true,
+		},
+		{
+			name: "invalid ttl tag",
+			tags: []types.Tag{{Key: aws.String(ttlTagKey), Value: aws.String("invalid")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, ok := userTTL(tt.tags)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, ttl)
+		})
+	}
+}
+
+func TestAccessKeyExpiration(t *testing.T) {
+	created := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	expiration := accessKeyExpiration(&types.AccessKey{CreateDate: &created}, time.Hour)
+	assert.Equal(t, created.Add(time.Hour), expiration)
+
+	expiration = accessKeyExpiration(&types.AccessKey{}, time.Hour)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiration, time.Minute)
+}
+
+func TestReapExpiredAccessKeys(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-2 * time.Hour)
+	valid := now.Add(-30 * time.Minute)
+
+	ttlUser := BuildUsername(testNamespace, "ttl")
+	regularUser := BuildUsername(testNamespace, "regular")
+
+	for scenario, fn := range map[string]func(t *testing.T){
+		"DeletesExpiredKeys": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			iamMock := omocks.NewIAM(t)
+			iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{PathPrefix: aws.String("/cosi/" + testClusterID + "/")}).Return(&iam.ListUsersOutput{
+				Users:       []types.User{{UserName: aws.String(ttlUser)}},
+				IsTruncated: true,
+				Marker:      aws.String("next"),
+			}, nil).Once()
+			iamMock.On("ListUsers", mock.Anything, &iam.ListUsersInput{
+				Marker:     aws.String("next"),
+				PathPrefix: aws.String("/cosi/" + testClusterID + "/"),
+			}).Return(&iam.ListUsersOutput{
+				Users: []types.User{{UserName: aws.String(regularUser)}, {UserName: aws.String("unmanaged")}},
+			}, nil).Once()
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String(ttlUser)}).Return(&iam.ListUserTagsOutput{
+				Tags: []types.Tag{{Key: aws.String(ttlTagKey), Value: aws.String("1h0m0s")}},
+			}, nil).Once()
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String(regularUser)}).Return(&iam.ListUserTagsOutput{}, nil).Once()
+			// the user belongs to a different connection of the cluster, so its keys are not listed
+			iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String("unmanaged")}).Return(&iam.ListUserTagsOutput{
+				Tags: []types.Tag{
+					{Key: aws.String(ttlTagKey), Value: aws.String("1h0m0s")},
+					{Key: aws.String(connectionTagKey), Value: aws.String("other.id")},
+				},
+			}, nil).Once()
+			iamMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(&iam.ListAccessKeysOutput{
+				AccessKeyMetadata: []types.AccessKeyMetadata{
+					{AccessKeyId: aws.String("expired"), CreateDate: &expired},
+					{AccessKeyId: aws.String("valid"), CreateDate: &valid},
+				},
+			}, nil).Once()
+			iamMock.On("DeleteAccessKey", mock.Anything, &iam.DeleteAccessKeyInput{
+				AccessKeyId: aws.String("expired"),
+				UserName:    aws.String(ttlUser),
+			}).Return(&iam.DeleteAccessKeyOutput{}, nil).Once()
+
+			server := Server{
+				namespace: testNamespace,
+				backendID: testID,
+				clusterID: testClusterID,
+				iamClient: func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			assert.NoError(t, server.reapExpiredAccessKeys(ctx, now))
+		},
+		"ErrorGettingIAMClient": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server := Server{
+				namespace: testNamespace,
+				iamClient: func(context.Context) (IAM, error) { return nil, errors.New("failed") },
+			}
+
+			assert.Error(t, server.reapExpiredAccessKeys(ctx, now))
+		},
+		"ErrorListingUsers": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			iamMock := omocks.NewIAM(t)
+			iamMock.On("ListUsers", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+
+			server := Server{
+				namespace: testNamespace,
+				iamClient: func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			assert.Error(t, server.reapExpiredAccessKeys(ctx, now))
+		},
+		"ErrorDeletingKeyDoesNotStopReaper": func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			iamMock := omocks.NewIAM(t)
+			iamMock.On("ListUsers", mock.Anything, mock.Anything).Return(&iam.ListUsersOutput{
+				Users: []types.User{{UserName: aws.String(ttlUser)}, {UserName: aws.String(regularUser)}},
+			}, nil).Once()
+			iamMock.On("ListUserTags", mock.Anything, mock.Anything).Return(&iam.ListUserTagsOutput{
+				Tags: []types.Tag{{Key: aws.String(ttlTagKey), Value: aws.String("1h0m0s")}},
+			}, nil).Twice()
+			iamMock.On("ListAccessKeys", mock.Anything, mock.Anything).Return(&iam.ListAccessKeysOutput{
+				AccessKeyMetadata: []types.AccessKeyMetadata{{AccessKeyId: aws.String("expired"), CreateDate: &expired}},
+			}, nil).Twice()
+			iamMock.On("DeleteAccessKey", mock.Anything, mock.Anything).Return(nil, errors.New("failed")).Once()
+			iamMock.On("DeleteAccessKey", mock.Anything, mock.Anything).Return(&iam.DeleteAccessKeyOutput{}, nil).Once()
+
+			server := Server{
+				namespace: testNamespace,
+				iamClient: func(context.Context) (IAM, error) { return iamMock, nil },
+			}
+
+			assert.ErrorContains(t, server.reapExpiredAccessKeys(ctx, now), ttlUser)
+		},
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func TestStartStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		(&Server{}).Start(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reaper did not stop")
+	}
+}
+
+func TestRunPassEndsBeforeNextPass(t *testing.T) {
+	ctx, cancel := testcontext.New(t)
+	defer cancel()
+
+	// hung pass is interrupted, once the next one is due
+	err := runPass(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+const (
+	// accessTagKey is the IAM user and role tag storing name of the BucketAccess, for which it was created.
+	accessTagKey = "cosi.dellemc.com/access"
+
+	// nameHashLength is the length of the hash suffix replacing the truncated part of too long names.
+	nameHashLength = 8
+)
+
+// errNameCollision is returned when IAM user or role of the name already belongs to a different BucketAccess.
+var errNameCollision = errors.New("name is already used by a different bucket access")
+
+// UsernameTemplateData holds variables available to the username template.
+type UsernameTemplateData struct {
+	// Namespace is the ObjectScale namespace, in which the user is created.
+	Namespace string
+	// AccessName is the name of the BucketAccess.
+	AccessName string
+}
+
+// newUsernameTemplate parses the username template from the configuration. Nil is returned if it is not configured.
+func newUsernameTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("username").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse username template: %w", err)
+	}
+
+	// Usernames must be unique per access, otherwise revoking one access would delete credentials of another.
+	first, err := renderUsername(tmpl, UsernameTemplateData{Namespace: "namespace", AccessName: "first"})
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := renderUsername(tmpl, UsernameTemplateData{Namespace: "namespace", AccessName: "second"})
+	if err != nil {
+		return nil, err
+	}
+
+	if first == second {
+		return nil, errors.New("username template must depend on .AccessName")
+	}
+
+	return tmpl, nil
+}
+
+// renderUsername renders the username template, without truncating the result.
+func renderUsername(tmpl *template.Template, data UsernameTemplateData) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render username template: %w", err)
+	}
+
+	if strings.TrimSpace(b.String()) == "" {
+		return "", errors.New("username template rendered empty username")
+	}
+
+	return b.String(), nil
+}
+
+// buildUsername builds name of the IAM user for the bucket access, using the configured template if any.
+func (s *Server) buildUsername(namespace, access string) (string, error) {
+	if s.usernameTemplate == nil {
+		return BuildUsername(namespace, access), nil
+	}
+
+	raw, err := renderUsername(s.usernameTemplate, UsernameTemplateData{Namespace: namespace, AccessName: access})
+	if err != nil {
+		return "", err
+	}
+
+	return truncateName(raw), nil
+}
+
+// usernameFor returns name of the IAM user for the bucket access. Older versions of the driver cut too long default
+// usernames without the hash suffix, so the user of such name is reused, if it exists and was not created
+// for a different access. Otherwise, the user would be leaked on upgrade, and a new one created for the access.
+func (s *Server) usernameFor(ctx context.Context, iamClient IAM, namespace, access string) (string, error) {
+	userName, err := s.buildUsername(namespace, access)
+	if err != nil {
+		return "", err
+	}
+
+	legacyName := legacyUsername(namespace, access)
+	if s.usernameTemplate != nil || legacyName == userName {
+		return userName, nil
+	}
+
+	_, err = iamClient.GetUser(ctx, &iam.GetUserInput{UserName: &legacyName})
+	if isNoSuchEntity(err) {
+		return userName, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed getting user %s: %w", legacyName, err)
+	}
+
+	err = checkUserAccess(ctx, iamClient, legacyName, access)
+	if errors.Is(err, errNameCollision) {
+		return userName, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	log.Infof("Reusing user %s created by older version of the driver for bucket access %s", legacyName, access)
+
+	return legacyName, nil
+}
+
+// legacyUsername builds name of the IAM user as older versions of the driver did, cutting too long names.
+func legacyUsername(namespace, access string) string {
+	raw := fmt.Sprintf("%v-user-%v", namespace, access)
+	if len(raw) > maxUsernameLength {
+		raw = raw[:maxUsernameLength]
+	}
+
+	return raw
+}
+
+// truncateName shortens names exceeding the IAM limit. The truncated part is replaced by the hash of the whole name,
+// so names sharing a long prefix remain distinct.
+func truncateName(raw string) string {
+	if len(raw) <= maxUsernameLength {
+		return raw
+	}
+
+	sum := sha256.Sum256([]byte(raw))
+	suffix := hex.EncodeToString(sum[:])[:nameHashLength]
+
+	return raw[:maxUsernameLength-nameHashLength-1] + "-" + suffix
+}
+
+// accessTags returns tags recording the BucketAccess, for which the IAM user or role is created.
+func accessTags(accessName string) []types.Tag {
+	return []types.Tag{{Key: aws.String(accessTagKey), Value: aws.String(accessName)}}
+}
+
+// checkAccessTag verifies that the IAM user or role was created for the BucketAccess.
+// Entities without the tag, created by older versions of the driver, are accepted, as it cannot be told
+// for which access they were created. Thus, the check does not protect users and roles created before upgrade
+// from the collisions, which older versions were prone to.
+func checkAccessTag(tags []types.Tag, accessName string) error {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) != accessTagKey {
+			continue
+		}
+
+		if owner := aws.ToString(tag.Value); owner != accessName {
+			return fmt.Errorf("%w: created for %s", errNameCollision, owner)
+		}
+	}
+
+	return nil
+}
+
+// checkUserAccess verifies that the existing IAM user was created for the BucketAccess, before it is reused.
+func checkUserAccess(ctx context.Context, iamClient IAM, userName, accessName string) error {
+	tags, err := iamClient.ListUserTags(ctx, &iam.ListUserTagsInput{UserName: &userName})
+	if err != nil {
+		return fmt.Errorf("failed listing user tags: %w", err)
+	}
+
+	return checkAccessTag(tags.Tags, accessName)
+}
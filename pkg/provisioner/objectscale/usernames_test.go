@@ -0,0 +1,170 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package objectscale
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/cosi/pkg/internal/testcontext"
+	omocks "github.com/dell/cosi/pkg/provisioner/objectscale/mocks"
+)
+
+func TestNewUsernameTemplate(t *testing.T) {
+	tmpl, err := newUsernameTemplate("")
+	assert.NoError(t, err)
+	assert.Nil(t, tmpl)
+
+	tmpl, err = newUsernameTemplate("{{ .Namespace }}-{{ .AccessName }}")
+	assert.NoError(t, err)
+	assert.NotNil(t, tmpl)
+
+	for _, text := range []string{"{{ .Namespace", "{{ .Unknown }}", "{{ .Namespace }}", "{{ if false }}{{ .AccessName }}{{ end }}"} {
+		_, err = newUsernameTemplate(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestServerBuildUsername(t *testing.T) {
+	tmpl, err := newUsernameTemplate("cosi-{{ .Namespace }}-{{ .AccessName }}")
+	assert.NoError(t, err)
+
+	server := Server{}
+
+	username, err := server.buildUsername("ns1", "access")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1-user-access", username)
+
+	server.usernameTemplate = tmpl
+
+	username, err = server.buildUsername("ns1", "access")
+	assert.NoError(t, err)
+	assert.Equal(t, "cosi-ns1-access", username)
+}
+
+func TestTruncateName(t *testing.T) {
+	prefix := strings.Repeat("a", maxUsernameLength)
+
+	first := truncateName(prefix + "-first")
+	second := truncateName(prefix + "-second")
+
+	assert.Len(t, first, maxUsernameLength)
+	assert.Len(t, second, maxUsernameLength)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, "short", truncateName("short"))
+}
+
+func TestUsernameFor(t *testing.T) {
+	longAccess := strings.Repeat("a", maxUsernameLength)
+	legacyName := legacyUsername(testNamespace, longAccess)
+	userName := BuildUsername(testNamespace, longAccess)
+	require.NotEqual(t, legacyName, userName)
+
+	getLegacyUser := func(iamMock *omocks.IAM) *mock.Call {
+		return iamMock.On("GetUser", mock.Anything, &iam.GetUserInput{UserName: aws.String(legacyName)})
+	}
+
+	listLegacyUserTags := func(iamMock *omocks.IAM) *mock.Call {
+		return iamMock.On("ListUserTags", mock.Anything, &iam.ListUserTagsInput{UserName: aws.String(legacyName)})
+	}
+
+	for scenario, tc := range map[string]struct {
+		access   string
+		template bool
+		setup    func(iamMock *omocks.IAM)
+		expected string
+		wantErr  bool
+	}{
+		"short name": {
+			access:   "access",
+			setup:    func(*omocks.IAM) {},
+			expected: BuildUsername(testNamespace, "access"),
+		},
+		"username template": {
+			access:   longAccess,
+			template: true,
+			setup:    func(*omocks.IAM) {},
+			expected: truncateName("cosi-" + longAccess),
+		},
+		"legacy user not found": {
+			access: longAccess,
+			setup: func(iamMock *omocks.IAM) {
+				getLegacyUser(iamMock).Return(nil, &types.NoSuchEntityException{}).Once()
+			},
+			expected: userName,
+		},
+		"legacy user without access tag": {
+			access: longAccess,
+			setup: func(iamMock *omocks.IAM) {
+				getLegacyUser(iamMock).Return(&iam.GetUserOutput{User: &types.User{UserName: aws.String(legacyName)}}, nil).Once()
+				listLegacyUserTags(iamMock).Return(&iam.ListUserTagsOutput{}, nil).Once()
+			},
+			expected: legacyName,
+		},
+		"legacy user of other access": {
+			access: longAccess,
+			setup: func(iamMock *omocks.IAM) {
+				getLegacyUser(iamMock).Return(&iam.GetUserOutput{User: &types.User{UserName: aws.String(legacyName)}}, nil).Once()
+				listLegacyUserTags(iamMock).Return(&iam.ListUserTagsOutput{Tags: accessTags("other-access")}, nil).Once()
+			},
+			expected: userName,
+		},
+		"error getting legacy user": {
+			access: longAccess,
+			setup: func(iamMock *omocks.IAM) {
+				getLegacyUser(iamMock).Return(nil, errors.New("connection refused")).Once()
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(scenario, func(t *testing.T) {
+			ctx, cancel := testcontext.New(t)
+			defer cancel()
+
+			server := Server{}
+			if tc.template {
+				tmpl, err := newUsernameTemplate("cosi-{{ .AccessName }}")
+				require.NoError(t, err)
+
+				server.usernameTemplate = tmpl
+			}
+
+			iamMock := omocks.NewIAM(t)
+			tc.setup(iamMock)
+
+			name, err := server.usernameFor(ctx, iamMock, testNamespace, tc.access)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestCheckAccessTag(t *testing.T) {
+	other := types.Tag{Key: aws.String("other"), Value: aws.String("value")}
+
+	assert.NoError(t, checkAccessTag(nil, "access"))
+	assert.NoError(t, checkAccessTag([]types.Tag{other}, "access"))
+	assert.NoError(t, checkAccessTag(append(accessTags("access"), other), "access"))
+
+	err := checkAccessTag(accessTags("other-access"), "access")
+	assert.True(t, errors.Is(err, errNameCollision))
+}
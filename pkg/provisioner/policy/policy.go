@@ -10,7 +10,21 @@
 // defines structures and functions for processing and comparing policies.
 package policy
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	// EffectAllow is the effect of statement granting permissions.
+	EffectAllow = "Allow"
+	// EffectDeny is the effect of statement explicitly denying permissions.
+	EffectDeny = "Deny"
+)
 
 type StatementEntry struct {
 	Effect    string            `json:"Effect"`
@@ -18,6 +32,44 @@ type StatementEntry struct {
 	Resource  []string          `json:"Resource"`
 	Principal map[string]string `json:"Principal,omitempty"`
 	Sid       string            `json:"Sid,omitempty"`
+	Condition Condition         `json:"Condition,omitempty"`
+}
+
+// Condition maps condition operators (e.g. IpAddress) to condition keys (e.g. aws:SourceIp) and their values.
+type Condition map[string]map[string]ConditionValues
+
+// ConditionValues is a list of values of a single condition key.
+// In JSON it can be represented either by a single value, or by an array of values.
+// The values are strings, booleans (e.g. of aws:SecureTransport) or numbers (e.g. of s3:max-keys),
+// decoded as string, bool and json.Number, so the policy is encoded back unchanged.
+type ConditionValues []any
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *ConditionValues) UnmarshalJSON(b []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+
+	for _, value := range values {
+		switch value.(type) {
+		case string, bool, json.Number:
+		default:
+			return fmt.Errorf("invalid condition value %v, should be string, number or boolean", value)
+		}
+	}
+
+	*v = values
+
+	return nil
 }
 
 type Document struct {
@@ -95,5 +147,102 @@ func (s *StatementEntry) Equal(s2 *StatementEntry) bool {
 		}
 	}
 
+	return s.Condition.Equal(s2.Condition)
+}
+
+// AddCondition adds values to the condition key under the given operator, e.g.:
+//
+//	statement.AddCondition("IpAddress", "aws:SourceIp", "10.0.0.0/8")
+func (s *StatementEntry) AddCondition(operator, key string, values ...string) {
+	if s.Condition == nil {
+		s.Condition = Condition{}
+	}
+
+	if s.Condition[operator] == nil {
+		s.Condition[operator] = map[string]ConditionValues{}
+	}
+
+	for _, value := range values {
+		s.Condition[operator][key] = append(s.Condition[operator][key], value)
+	}
+}
+
+// Check equality between conditions.
+func (c Condition) Equal(c2 Condition) bool {
+	if len(c) != len(c2) {
+		return false
+	}
+
+	for operator, keys := range c {
+		keys2, ok := c2[operator]
+		if !ok || len(keys) != len(keys2) {
+			return false
+		}
+
+		for key, values := range keys {
+			values2, ok := keys2[key]
+			if !ok || len(values) != len(values2) {
+				return false
+			}
+
+			for i, v := range values {
+				if v != values2[i] {
+					return false
+				}
+			}
+		}
+	}
+
 	return true
 }
+
+// Validate checks if the statement contains all elements required in the bucket policy.
+func (s *StatementEntry) Validate() error {
+	if s.Effect != EffectAllow && s.Effect != EffectDeny {
+		return fmt.Errorf("invalid effect %q, should be %s or %s", s.Effect, EffectAllow, EffectDeny)
+	}
+
+	if len(s.Action) == 0 {
+		return errors.New("statement has no actions")
+	}
+
+	for _, a := range s.Action {
+		if a == "" {
+			return errors.New("statement contains empty action")
+		}
+	}
+
+	if len(s.Resource) == 0 {
+		return errors.New("statement has no resources")
+	}
+
+	for _, r := range s.Resource {
+		if r == "" {
+			return errors.New("statement contains empty resource")
+		}
+	}
+
+	return nil
+}
+
+// GrantsAction checks if the statement allows the given action.
+// Wildcards in statement actions are taken into account, and actions are compared case-insensitively.
+// Malformed action pattern is returned as an error, as it cannot be decided whether it grants the action.
+func (s *StatementEntry) GrantsAction(action string) (bool, error) {
+	if s.Effect != EffectAllow {
+		return false, nil
+	}
+
+	for _, a := range s.Action {
+		ok, err := path.Match(strings.ToLower(a), strings.ToLower(action))
+		if err != nil {
+			return false, fmt.Errorf("invalid action %q: %w", a, err)
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
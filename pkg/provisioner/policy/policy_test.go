@@ -9,12 +9,14 @@
 package policy_test
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/dell/cosi/pkg/provisioner/policy"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewFromJSON(t *testing.T) {
@@ -340,6 +342,83 @@ func TestEqual(t *testing.T) {
 			}`,
 			isEqual: false,
 		},
+		{
+			name: "equal conditions",
+			jsonString1: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject"],
+						"Resource": ["arn:aws:s3:::my-bucket/*"],
+						"Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}
+					}
+				]
+			}`,
+			jsonString2: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject"],
+						"Resource": ["arn:aws:s3:::my-bucket/*"],
+						"Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}
+					}
+				]
+			}`,
+			isEqual: true,
+		},
+		{
+			name: "different condition values",
+			jsonString1: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject"],
+						"Resource": ["arn:aws:s3:::my-bucket/*"],
+						"Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "192.168.0.0/16"]}}
+					}
+				]
+			}`,
+			jsonString2: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject"],
+						"Resource": ["arn:aws:s3:::my-bucket/*"],
+						"Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}
+					}
+				]
+			}`,
+			isEqual: false,
+		},
+		{
+			name: "missing condition",
+			jsonString1: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject"],
+						"Resource": ["arn:aws:s3:::my-bucket/*"],
+						"Condition": {"NotIpAddress": {"aws:SourceIp": "10.0.0.0/8"}}
+					}
+				]
+			}`,
+			jsonString2: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject"],
+						"Resource": ["arn:aws:s3:::my-bucket/*"]
+					}
+				]
+			}`,
+			isEqual: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -352,3 +431,237 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement policy.StatementEntry
+		wantErr   bool
+	}{
+		{
+			name: "valid statement",
+			statement: policy.StatementEntry{
+				Effect:   policy.EffectAllow,
+				Action:   []string{"s3:GetObject"},
+				Resource: []string{"arn:aws:s3:::my-bucket/*"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid effect",
+			statement: policy.StatementEntry{
+				Effect:   "allow",
+				Action:   []string{"s3:GetObject"},
+				Resource: []string{"arn:aws:s3:::my-bucket/*"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no actions",
+			statement: policy.StatementEntry{
+				Effect:   policy.EffectDeny,
+				Resource: []string{"arn:aws:s3:::my-bucket/*"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty action",
+			statement: policy.StatementEntry{
+				Effect:   policy.EffectAllow,
+				Action:   []string{""},
+				Resource: []string{"arn:aws:s3:::my-bucket/*"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no resources",
+			statement: policy.StatementEntry{
+				Effect: policy.EffectAllow,
+				Action: []string{"s3:GetObject"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty resource",
+			statement: policy.StatementEntry{
+				Effect:   policy.EffectAllow,
+				Action:   []string{"s3:GetObject"},
+				Resource: []string{""},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.statement.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrantsAction(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement policy.StatementEntry
+		action    string
+		expected  bool
+		wantErr   bool
+	}{
+		{
+			name:      "exact match",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"s3:PutBucketPolicy"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  true,
+		},
+		{
+			name:      "case insensitive match",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"S3:putbucketpolicy"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  true,
+		},
+		{
+			name:      "global wildcard",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"*"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  true,
+		},
+		{
+			name:      "service wildcard",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"s3:*"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  true,
+		},
+		{
+			name:      "prefix wildcard",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"s3:Put*"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  true,
+		},
+		{
+			name:      "different action",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"s3:GetObject", "s3:Get*"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  false,
+		},
+		{
+			name:      "deny statement",
+			statement: policy.StatementEntry{Effect: policy.EffectDeny, Action: []string{"*"}},
+			action:    "s3:PutBucketPolicy",
+			expected:  false,
+		},
+		{
+			name:      "malformed pattern",
+			statement: policy.StatementEntry{Effect: policy.EffectAllow, Action: []string{"s3:GetObject", "s3:[Put*"}},
+			action:    "s3:PutBucketPolicy",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants, err := tt.statement.GrantsAction(tt.action)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, grants)
+		})
+	}
+}
+
+func TestConditionValuesUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		jsonString  string
+		expected    policy.ConditionValues
+		expectedErr bool
+	}{
+		{
+			name:       "single value",
+			jsonString: `"10.0.0.0/8"`,
+			expected:   policy.ConditionValues{"10.0.0.0/8"},
+		},
+		{
+			name:       "multiple values",
+			jsonString: `["10.0.0.0/8", "192.168.0.0/16"]`,
+			expected:   policy.ConditionValues{"10.0.0.0/8", "192.168.0.0/16"},
+		},
+		{
+			name:       "boolean value",
+			jsonString: `false`,
+			expected:   policy.ConditionValues{false},
+		},
+		{
+			name:       "mixed values",
+			jsonString: `[10, "20", true]`,
+			expected:   policy.ConditionValues{json.Number("10"), "20", true},
+		},
+		{
+			name:        "invalid value",
+			jsonString:  `{"key": "value"}`,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid nested value",
+			jsonString:  `[["10.0.0.0/8"]]`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var values policy.ConditionValues
+
+			err := values.UnmarshalJSON([]byte(tt.jsonString))
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, tt.expectedErr)
+				return
+			}
+
+			if !tt.expectedErr {
+				assert.Equal(t, tt.expected, values)
+			}
+		})
+	}
+}
+
+func TestConditionValuesRoundTrip(t *testing.T) {
+	// existing bucket policy with conditions, which are not strings
+	existing := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":["s3:*"],"Resource":["arn:aws:s3:::my-bucket/*"],` +
+		`"Principal":{"AWS":"*"},"Condition":{"Bool":{"aws:SecureTransport":false},"NumericLessThanEquals":{"s3:max-keys":[10,1.5]}}}]}`
+
+	doc, err := policy.NewFromJSON(existing)
+	require.NoError(t, err)
+
+	generatedJSON, err := doc.ToJSON()
+	require.NoError(t, err)
+	assert.Contains(t, generatedJSON, `"Condition":{"Bool":{"aws:SecureTransport":[false]},"NumericLessThanEquals":{"s3:max-keys":[10,1.5]}}`)
+
+	again, err := policy.NewFromJSON(generatedJSON)
+	require.NoError(t, err)
+	assert.True(t, doc.Equal(&again))
+}
+
+func TestAddCondition(t *testing.T) {
+	statement := policy.StatementEntry{
+		Effect:   policy.EffectAllow,
+		Action:   []string{"s3:GetObject"},
+		Resource: []string{"arn:aws:s3:::my-bucket/*"},
+	}
+
+	statement.AddCondition("IpAddress", "aws:SourceIp", "10.0.0.0/8")
+	statement.AddCondition("IpAddress", "aws:SourceIp", "192.168.0.0/16")
+
+	assert.Equal(t, policy.Condition{
+		"IpAddress": {"aws:SourceIp": {"10.0.0.0/8", "192.168.0.0/16"}},
+	}, statement.Condition)
+
+	generatedJSON, err := (&policy.Document{Statement: []policy.StatementEntry{statement}}).ToJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, generatedJSON, `"Condition":{"IpAddress":{"aws:SourceIp":["10.0.0.0/8","192.168.0.0/16"]}}`)
+}
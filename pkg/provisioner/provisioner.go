@@ -28,6 +28,12 @@ import (
 // Server is an implementation of a provisioner server.
 type Server struct {
 	driverset *Driverset
+	// breakers fail requests to the unavailable backends fast. Nil if not used.
+	breakers *breakers
+	// limiters limit the requests to the backends with configured limits. Nil if not used.
+	limiters *limiters
+	// flights share a single execution between identical requests in progress. Nil if not used.
+	flights *flightGroup
 	cosi.UnimplementedProvisionerServer
 }
 
@@ -41,12 +47,24 @@ const (
 )
 
 // New initializes Server based on the config file.
+// Every backend has a circuit breaker, which fails requests fast after consecutive failures,
+// and the backends with configured limits have a limiter, which queues the requests over the limits.
+// Identical requests in progress at the same time are executed once, and share the result.
 func New(driverset *Driverset) *Server {
 	return &Server{
 		driverset: driverset,
+		breakers:  newBreakers(),
+		limiters:  newLimiters(),
+		flights:   &flightGroup{},
 	}
 }
 
+// OnBreakerStateChange sets the listener notified about the state changes of the circuit breakers of the backends.
+// It must be called before the server starts handling requests.
+func (s *Server) OnBreakerStateChange(listener BreakerListener) {
+	s.breakers.setListener(listener)
+}
+
 // DriverCreateBucket creates Bucket on specific Object Storage Platform.
 func (s *Server) DriverCreateBucket(ctx context.Context,
 	req *cosi.DriverCreateBucketRequest,
@@ -67,8 +85,13 @@ func (s *Server) DriverCreateBucket(ctx context.Context,
 		return nil, status.Error(codes.InvalidArgument, ErrInvalidBackendID)
 	}
 
-	// execute DriverCreateBucket from correct driver
-	return d.DriverCreateBucket(tracedCtx, req)
+	// execute DriverCreateBucket from correct driver, within its limits, unless its circuit breaker is open,
+	// or share the result of identical request in progress
+	return coalesce(tracedCtx, s.flights, flightKey(id, "CreateBucket", req), s.limiters.get(d).acquire, func(ctx context.Context) (*cosi.DriverCreateBucketResponse, error) {
+		return call(ctx, s.breakers.get(d), func() (*cosi.DriverCreateBucketResponse, error) {
+			return d.DriverCreateBucket(ctx, req)
+		})
+	})
 }
 
 // DriverDeleteBucket deletes Bucket on specific Object Storage Platform.
@@ -91,8 +114,13 @@ func (s *Server) DriverDeleteBucket(ctx context.Context,
 		return nil, status.Error(codes.InvalidArgument, ErrInvalidBackendID)
 	}
 
-	// execute DriverDeleteBucket from correct driver
-	return d.DriverDeleteBucket(tracedCtx, req)
+	// execute DriverDeleteBucket from correct driver, within its limits, unless its circuit breaker is open,
+	// or share the result of identical request in progress
+	return coalesce(tracedCtx, s.flights, flightKey(id, "DeleteBucket", req), s.limiters.get(d).acquire, func(ctx context.Context) (*cosi.DriverDeleteBucketResponse, error) {
+		return call(ctx, s.breakers.get(d), func() (*cosi.DriverDeleteBucketResponse, error) {
+			return d.DriverDeleteBucket(ctx, req)
+		})
+	})
 }
 
 // DriverGrantBucketAccess provides access to Bucket on specific Object Storage Platform.
@@ -115,8 +143,13 @@ func (s *Server) DriverGrantBucketAccess(ctx context.Context,
 		return nil, status.Error(codes.InvalidArgument, ErrInvalidBackendID)
 	}
 
-	// execute DriverGrantBucketAccess from correct driver
-	return d.DriverGrantBucketAccess(tracedCtx, req)
+	// execute DriverGrantBucketAccess from correct driver, within its limits, unless its circuit breaker is open,
+	// or share the result of identical request in progress
+	return coalesce(tracedCtx, s.flights, flightKey(id, "GrantBucketAccess", req), s.limiters.get(d).acquire, func(ctx context.Context) (*cosi.DriverGrantBucketAccessResponse, error) {
+		return call(ctx, s.breakers.get(d), func() (*cosi.DriverGrantBucketAccessResponse, error) {
+			return d.DriverGrantBucketAccess(ctx, req)
+		})
+	})
 }
 
 // DriverRevokeBucketAccess revokes access from Bucket on specific Object Storage Platform.
@@ -139,8 +172,13 @@ func (s *Server) DriverRevokeBucketAccess(ctx context.Context,
 		return nil, status.Error(codes.InvalidArgument, ErrInvalidBackendID)
 	}
 
-	// execute DriverRevokeBucketAccess from correct driver
-	return d.DriverRevokeBucketAccess(tracedCtx, req)
+	// execute DriverRevokeBucketAccess from correct driver, within its limits, unless its circuit breaker is open,
+	// or share the result of identical request in progress
+	return coalesce(tracedCtx, s.flights, flightKey(id, "RevokeBucketAccess", req), s.limiters.get(d).acquire, func(ctx context.Context) (*cosi.DriverRevokeBucketAccessResponse, error) {
+		return call(ctx, s.breakers.get(d), func() (*cosi.DriverRevokeBucketAccessResponse, error) {
+			return d.DriverRevokeBucketAccess(ctx, req)
+		})
+	})
 }
 
 // getID splits the string and returns ID from it
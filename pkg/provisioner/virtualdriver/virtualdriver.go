@@ -11,7 +11,12 @@
 package virtualdriver
 
 import (
+	"context"
+	"time"
+
 	cosi "sigs.k8s.io/container-object-storage-interface/proto"
+
+	"github.com/dell/cosi/pkg/provisioner/policy"
 )
 
 // Driver is an interface extending cosi.ProvisionerServer interface by ID method
@@ -36,3 +41,113 @@ type Driver interface {
 	// - DriverRevokeBucketAccessRequest.BucketID
 	ID() string
 }
+
+// Worker is an optional interface implemented by drivers, that need to run background tasks,
+// e.g. revoking expired credentials, for as long as the driver is serving requests.
+type Worker interface {
+	// Start runs background tasks of the driver. It blocks until the context is canceled.
+	Start(ctx context.Context)
+}
+
+// Prober is an optional interface implemented by drivers, that can check availability of the object storage platform.
+// It is used to close the circuit breaker of the driver, once the platform is available again.
+type Prober interface {
+	// Probe makes a cheap request to the object storage platform, and returns error if it is unavailable.
+	Probe(ctx context.Context) error
+}
+
+// Limits of the load put on the object storage platform by the driver operations.
+// Zero value of a field means it is not limited.
+type Limits struct {
+	// MaxInFlight is the maximum number of operations in progress at the same time.
+	MaxInFlight int
+	// RequestsPerSecond is the maximum average rate of started operations.
+	RequestsPerSecond float64
+	// Burst is the maximum number of operations started at once, over the RequestsPerSecond rate.
+	Burst int
+}
+
+// Limited is an optional interface implemented by drivers, whose operations should be limited,
+// e.g. to avoid throttling by the object storage platform.
+type Limited interface {
+	// Limits returns the limits of the driver operations.
+	Limits() Limits
+}
+
+// Capabilities of the driver, reported for troubleshooting.
+type Capabilities struct {
+	// Platform is the type of the object storage platform, e.g. "objectscale".
+	Platform string
+	// Protocols are the bucket access protocols supported by the driver, e.g. "s3".
+	Protocols []string
+	// Features are the optional features enabled by the configuration of the driver.
+	Features []string
+}
+
+// Describer is an optional interface implemented by drivers, that report their capabilities.
+type Describer interface {
+	// Capabilities returns the capabilities of the driver.
+	Capabilities() Capabilities
+}
+
+// DryRunner is an optional interface implemented by drivers, that can run without changing the object storage platform.
+type DryRunner interface {
+	// EnableDryRun makes the driver log the mutating calls to the object storage platform, instead of sending them,
+	// and synthesize their responses. It must be called before the driver serves any request.
+	EnableDryRun()
+}
+
+// Inspector is an optional interface implemented by drivers, that let the operators inspect the resources
+// managed on the object storage platform. All methods are read-only.
+type Inspector interface {
+	// ListBuckets returns names of the buckets in the namespace of the driver.
+	ListBuckets(ctx context.Context) ([]string, error)
+	// GetBucketPolicy returns the policy of the bucket. Empty document is returned, if the bucket has no policy.
+	GetBucketPolicy(ctx context.Context, bucketName string) (policy.Document, error)
+	// ListUsers returns the IAM users created by the driver, with their access keys.
+	ListUsers(ctx context.Context) ([]User, error)
+}
+
+// User is an IAM user created by the driver for bucket access.
+type User struct {
+	Namespace  string            `json:"namespace"`
+	Name       string            `json:"name"`
+	CreateDate time.Time         `json:"createDate"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	AccessKeys []AccessKey       `json:"accessKeys"`
+}
+
+// AccessKey is the metadata of the access key of a user. Secrets are never reported.
+type AccessKey struct {
+	ID         string    `json:"id"`
+	Status     string    `json:"status"`
+	CreateDate time.Time `json:"createDate"`
+}
+
+// Reconciler is an optional interface implemented by drivers, that detect the orphans left behind on the object
+// storage platform by failed or partially applied operations, on demand.
+type Reconciler interface {
+	// Reconcile finds the orphans, and removes them, if remove is true and nothing failed to be checked.
+	// The report is returned along with the error, if the detection is incomplete.
+	Reconcile(ctx context.Context, remove bool) (*OrphanReport, error)
+}
+
+// OrphanUser is an IAM user created by the driver, which is not used by any bucket access.
+type OrphanUser struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// OrphanStatement is a bucket policy statement added by the driver for a principal, which no longer exists.
+type OrphanStatement struct {
+	Bucket    string `json:"bucket"`
+	Principal string `json:"principal"`
+}
+
+// OrphanReport is the result of the reconciliation.
+type OrphanReport struct {
+	Users      []OrphanUser      `json:"users"`
+	Statements []OrphanStatement `json:"statements"`
+	// Removed is true if the orphans were removed, false if they were only reported.
+	Removed bool `json:"removed"`
+}
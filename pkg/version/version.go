@@ -0,0 +1,41 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+// Package version holds the version of the driver.
+package version
+
+import "runtime/debug"
+
+// Version of the driver. It is set at build time using
+// -ldflags "-X github.com/dell/cosi/pkg/version.Version=<version>".
+var Version = "dev"
+
+// Commit of the driver sources. It is set at build time using
+// -ldflags "-X github.com/dell/cosi/pkg/version.Commit=<commit>".
+var Commit = ""
+
+// readBuildInfo is replaced in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// GitCommit returns the commit of the driver sources. If it was not set at build time,
+// the VCS revision recorded by the Go toolchain is used, or "unknown" if there is none.
+func GitCommit() string {
+	if Commit != "" {
+		return Commit
+	}
+
+	if info, ok := readBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				return setting.Value
+			}
+		}
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,58 @@
+// Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// This software contains the intellectual property of Dell Inc.
+// or is licensed to Dell Inc. from third parties. Use of this software
+// and the intellectual property contained therein is expressly limited to the
+// terms and conditions of the License Agreement under which it is provided by or
+// on behalf of Dell Inc. or its subsidiaries.
+
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitCommit(t *testing.T) {
+	buildInfo := func(revision string) func() (*debug.BuildInfo, bool) {
+		return func() (*debug.BuildInfo, bool) {
+			return &debug.BuildInfo{Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: revision}}}, true
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		commit        string
+		readBuildInfo func() (*debug.BuildInfo, bool)
+		expected      string
+	}{
+		{
+			name:          "set at build time",
+			commit:        "abc123",
+			readBuildInfo: buildInfo("def456"),
+			expected:      "abc123",
+		},
+		{
+			name:          "from build info",
+			readBuildInfo: buildInfo("def456"),
+			expected:      "def456",
+		},
+		{
+			name:          "unknown",
+			readBuildInfo: func() (*debug.BuildInfo, bool) { return nil, false },
+			expected:      "unknown",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(commit string, read func() (*debug.BuildInfo, bool)) {
+				Commit, readBuildInfo = commit, read
+			}(Commit, readBuildInfo)
+
+			Commit, readBuildInfo = tc.commit, tc.readBuildInfo
+
+			assert.Equal(t, tc.expected, GitCommit())
+		})
+	}
+}